@@ -2,45 +2,132 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/escape-ship/ordersrv/config"
 	"github.com/escape-ship/ordersrv/internal/app"
 	"github.com/escape-ship/ordersrv/internal/kafka"
+	"github.com/escape-ship/ordersrv/internal/outbox"
+	"github.com/escape-ship/ordersrv/internal/service"
 	kafkaPkg "github.com/escape-ship/ordersrv/pkg/kafka"
 	"github.com/escape-ship/ordersrv/pkg/postgres"
 
-	_ "github.com/jackc/pgx/v5/stdlib" // pgx 드라이버 등록
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
 )
 
 func main() {
+	configPath := flag.String("config", "config.yaml", "path to config file")
+	flag.Parse()
+
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
-	cfg, err := config.New("config.yaml")
+	cfg, err := config.New(*configPath)
 	if err != nil {
-		logger.Error("App: config load error", "error", err)
+		logger.Error("App: config load error", "path", *configPath, "error", err)
 		os.Exit(1)
 	}
+	logger.Info("App: config loaded", "path", *configPath, "config", cfg)
+
+	var replicas []postgres.DBConnString
+	for _, h := range cfg.Database.ReplicaHosts {
+		host, port, _ := cfg.Database.ReplicaAddr(h) // Validate 에서 이미 확인함
+		replicas = append(replicas, makeDSN(cfg.Database, host, port))
+	}
+
+	dsn := makeDSN(cfg.Database, cfg.Database.Host, cfg.Database.Port)
+	dbOpts := []postgres.Option{
+		postgres.WithConnAttempts(cfg.Database.ConnAttempts),
+		postgres.WithConnTimeout(cfg.Database.ConnTimeout),
+		postgres.WithMaxOpenConns(cfg.Database.MaxOpenConns),
+		postgres.WithMaxIdleConns(cfg.Database.MaxIdleConns),
+		postgres.WithConnMaxLifetime(cfg.Database.ConnMaxLifetime),
+		postgres.WithTxRetries(cfg.Database.TxRetries),
+		postgres.WithReplicas(replicas...),
+		postgres.WithReplicaCheckInterval(cfg.Database.ReplicaCheckInterval),
+	}
 
-	db, err := postgres.New(makeDSN(cfg.Database))
+	var (
+		db        postgres.DBEngine
+		relayOpts []outbox.RelayOption
+	)
+	switch cfg.Database.Driver {
+	case config.DriverPgxPool:
+		// pgxpool 모드에서는 outbox 알림(LISTEN)으로 relay 를 바로 깨우고 sent 표시를 batch 로 보낸다.
+		var pool postgres.PoolEngine
+		pool, err = postgres.NewPool(dsn, dbOpts...)
+		if err == nil {
+			db = pool
+			relayOpts = append(relayOpts, outbox.WithNotifier(pool), outbox.WithPool(pool.Pool()))
+		}
+	default:
+		db, err = postgres.New(dsn, dbOpts...)
+	}
 	if err != nil {
 		logger.Error("App: database connection error", "error", err)
 		os.Exit(1)
 	}
+	defer db.Close()
 
-	brokers := []string{"kafka:9092"}
-	topicMap := map[string]kafkaPkg.MessageHandler{
-		"payment-succeeded": kafka.PaymentSucceededHandler,
+	orderService := service.NewOrderController(db, service.WithPaymentMethods(cfg.App.PaymentMethods...))
+	paymentHandler := kafka.NewPaymentHandler(orderService)
+
+	// config 의 kafka.consumers 에서 이름으로 참조하는 핸들러
+	handlers := map[string]kafkaPkg.MessageHandler{
+		"payment_succeeded":     paymentHandler.PaymentSucceeded,
+		"payment_refunded":      paymentHandler.PaymentRefunded,
+		"payment_refund_failed": paymentHandler.PaymentRefundFailed,
+	}
+	topicMap := make(map[string]kafkaPkg.MessageHandler, len(cfg.Kafka.Consumers))
+	for topic, name := range cfg.Kafka.Consumers {
+		handler, ok := handlers[name]
+		if !ok {
+			logger.Error("App: unknown kafka handler", "topic", topic, "handler", name)
+			os.Exit(1)
+		}
+		topicMap[topic] = handler
+	}
+
+	security, err := makeKafkaSecurity(cfg.Kafka)
+	if err != nil {
+		logger.Error("App: kafka security config error", "error", err)
+		os.Exit(1)
 	}
-	groupID := "order-group"
-	consumer := kafkaPkg.NewConsumer(brokers, topicMap, groupID)
+	brokers := cfg.Kafka.Brokers
+	deadLetter := kafkaPkg.NewPublisher(brokers, cfg.Kafka.DeadLetterTopic, kafkaPkg.WithPublisherSecurity(security))
+	consumer := kafkaPkg.NewConsumer(brokers, topicMap, cfg.Kafka.GroupID,
+		kafkaPkg.WithRetryPolicy(kafkaPkg.RetryPolicy{
+			MaxAttempts:    cfg.Kafka.Retry.MaxAttempts,
+			InitialBackoff: cfg.Kafka.Retry.InitialBackoff,
+			MaxBackoff:     cfg.Kafka.Retry.MaxBackoff,
+		}),
+		kafkaPkg.WithDeadLetter(deadLetter),
+		kafkaPkg.WithCommitInterval(cfg.Kafka.CommitInterval),
+		kafkaPkg.WithSecurity(security),
+		kafkaPkg.WithMaxLag(cfg.Kafka.MaxLag),
+	)
+	defer deadLetter.Close()
+
+	// Outbox relay: 토픽별 publisher
+	publishers := make(map[string]kafkaPkg.Publisher)
+	for _, topic := range []string{service.TopicOrderCreated, service.TopicOrderStatusChanged, service.TopicRefundRequested} {
+		publishers[topic] = kafkaPkg.NewPublisher(brokers, topic, kafkaPkg.WithPublisherSecurity(security))
+	}
+	relay := outbox.NewRelay(db, publishers, relayOpts...)
 
 	// App 인스턴스 생성
-	application := app.NewApp(db, consumer)
+	application := app.NewApp(cfg.App, db, orderService, consumer, relay)
 
 	// Context와 signal handling 설정
 	ctx, cancel := context.WithCancel(context.Background())
@@ -72,14 +159,65 @@ func main() {
 	logger.Info("App: graceful shutdown completed")
 }
 
-// config.Database 값 사용
-func makeDSN(db config.Database) postgres.DBConnString {
-	return postgres.DBConnString(
-		fmt.Sprintf(
-			"postgres://%s:%s@%s:%d/%s?sslmode=%s&search_path=%s",
-			db.User, db.Password,
-			db.Host, db.Port,
-			db.DataBaseName, db.SSLMode, db.SchemaName,
-		),
-	)
+// config.Database 값 사용. host/port 만 primary 와 replica 가 다르다.
+// user/password 에 @, :, / 같은 문자가 있어도 깨지지 않도록 url.URL 로 만든다.
+func makeDSN(db config.Database, host string, port int) postgres.DBConnString {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(db.User, db.Password),
+		Host:     net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:     "/" + db.DataBaseName,
+		RawQuery: url.Values{"sslmode": {db.SSLMode}, "search_path": {db.SchemaName}}.Encode(),
+	}
+	return postgres.DBConnString(u.String())
+}
+
+// config.Kafka 의 TLS/SASL 설정으로 kafka 연결 보안 설정을 만든다.
+func makeKafkaSecurity(k config.Kafka) (kafkaPkg.Security, error) {
+	var sec kafkaPkg.Security
+
+	if k.TLS.Enabled {
+		tlsCfg := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: k.TLS.InsecureSkipVerify,
+		}
+		if k.TLS.CAFile != "" {
+			ca, err := os.ReadFile(k.TLS.CAFile)
+			if err != nil {
+				return sec, fmt.Errorf("read kafka CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return sec, fmt.Errorf("no certificates found in %s", k.TLS.CAFile)
+			}
+			tlsCfg.RootCAs = pool
+		}
+		if k.TLS.CertFile != "" || k.TLS.KeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(k.TLS.CertFile, k.TLS.KeyFile)
+			if err != nil {
+				return sec, fmt.Errorf("load kafka client certificate: %w", err)
+			}
+			tlsCfg.Certificates = []tls.Certificate{cert}
+		}
+		sec.TLS = tlsCfg
+	}
+
+	switch strings.ToLower(k.SASL.Mechanism) {
+	case "":
+	case "plain":
+		sec.SASL = plain.Mechanism{Username: k.SASL.Username, Password: k.SASL.Password}
+	case "scram-sha-256", "scram-sha-512":
+		algo := scram.SHA256
+		if strings.EqualFold(k.SASL.Mechanism, "scram-sha-512") {
+			algo = scram.SHA512
+		}
+		mech, err := scram.Mechanism(algo, k.SASL.Username, k.SASL.Password)
+		if err != nil {
+			return sec, fmt.Errorf("kafka SASL: %w", err)
+		}
+		sec.SASL = mech
+	default:
+		return sec, fmt.Errorf("unsupported kafka SASL mechanism %q", k.SASL.Mechanism)
+	}
+	return sec, nil
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/escape-ship/ordersrv/config"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestMakeDSN(t *testing.T) {
+	db := config.Database{
+		User:         "order@svc",
+		Password:     "p@ss:w/rd?#%",
+		DataBaseName: "escape",
+		SchemaName:   "orders",
+		SSLMode:      "disable",
+	}
+	tests := []struct {
+		host string
+		port int
+	}{
+		{"db", 5432},
+		{"::1", 6432},
+	}
+	for _, tt := range tests {
+		dsn := makeDSN(db, tt.host, tt.port)
+		cfg, err := pgconn.ParseConfig(string(dsn))
+		if err != nil {
+			t.Fatalf("ParseConfig(%q) error = %v", dsn, err)
+		}
+		if cfg.User != db.User || cfg.Password != db.Password {
+			t.Errorf("makeDSN() user/password = %q/%q, want %q/%q", cfg.User, cfg.Password, db.User, db.Password)
+		}
+		if cfg.Host != tt.host || int(cfg.Port) != tt.port || cfg.Database != db.DataBaseName {
+			t.Errorf("makeDSN() = %s:%d/%s, want %s:%d/%s", cfg.Host, cfg.Port, cfg.Database, tt.host, tt.port, db.DataBaseName)
+		}
+		if got := cfg.RuntimeParams["search_path"]; got != db.SchemaName {
+			t.Errorf("makeDSN() search_path = %q, want %q", got, db.SchemaName)
+		}
+	}
+}
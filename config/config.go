@@ -1,15 +1,37 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type (
 	Config struct {
+		App      App      `mapstructure:"app"`
 		Database Database `mapstructure:"database"`
+		Kafka    Kafka    `mapstructure:"kafka"`
+	}
+
+	App struct {
+		LogLevel string `mapstructure:"log_level"` // APP_LOG_LEVEL
+		Host     string `mapstructure:"host"`      // APP_HOST
+		Port     int    `mapstructure:"port"`      // APP_PORT
+
+		// PaymentMethods 는 주문에 허용할 결제 수단 목록이다. 비어있으면 결제 수단을 검사하지 않는다.
+		PaymentMethods []string `mapstructure:"payment_methods"` // APP_PAYMENT_METHODS (콤마로 구분)
+
+		// HealthPort 가 0 이 아니면 HTTP /healthz, /readyz 를 이 포트로 띄운다.
+		HealthPort          int           `mapstructure:"health_port"`           // APP_HEALTH_PORT
+		HealthCheckInterval time.Duration `mapstructure:"health_check_interval"` // APP_HEALTH_CHECK_INTERVAL
+		HealthCheckTimeout  time.Duration `mapstructure:"health_check_timeout"`  // APP_HEALTH_CHECK_TIMEOUT
 	}
 
 	Database struct {
@@ -20,27 +42,187 @@ type (
 		DataBaseName string `mapstructure:"database_name"` // DATABASE_DATABASE_NAME
 		SchemaName   string `mapstructure:"schema_name"`   // DATABASE_SCHEMA_NAME
 		SSLMode      string `mapstructure:"ssl_mode"`      // DATABASE_SSL_MODE
+		// Driver 는 DB 연결 방식이다. sql: database/sql(pgx stdlib), pgxpool: pgxpool 네이티브(COPY/batch/LISTEN 사용 가능)
+		Driver string `mapstructure:"driver"` // DATABASE_DRIVER
+
+		ConnAttempts    int           `mapstructure:"conn_attempts"`     // DATABASE_CONN_ATTEMPTS
+		ConnTimeout     time.Duration `mapstructure:"conn_timeout"`      // DATABASE_CONN_TIMEOUT
+		MaxOpenConns    int           `mapstructure:"max_open_conns"`    // DATABASE_MAX_OPEN_CONNS
+		MaxIdleConns    int           `mapstructure:"max_idle_conns"`    // DATABASE_MAX_IDLE_CONNS
+		ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"` // DATABASE_CONN_MAX_LIFETIME
+		TxRetries       int           `mapstructure:"tx_retries"`        // DATABASE_TX_RETRIES (직렬화 실패/데드락 재시도 횟수)
+
+		// ReplicaHosts 는 읽기 전용 replica 의 host 또는 host:port 목록이다. 포트가 없으면 Port 를 쓴다.
+		// 계정/DB/스키마 설정은 primary 와 같다.
+		ReplicaHosts         []string      `mapstructure:"replica_hosts"`          // DATABASE_REPLICA_HOSTS (콤마로 구분)
+		ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"` // DATABASE_REPLICA_CHECK_INTERVAL
+	}
+
+	Kafka struct {
+		Brokers         []string      `mapstructure:"brokers"`           // KAFKA_BROKERS (콤마로 구분)
+		GroupID         string        `mapstructure:"group_id"`          // KAFKA_GROUP_ID
+		DeadLetterTopic string        `mapstructure:"dead_letter_topic"` // KAFKA_DEAD_LETTER_TOPIC
+		CommitInterval  time.Duration `mapstructure:"commit_interval"`   // KAFKA_COMMIT_INTERVAL
+		MaxLag          int64         `mapstructure:"max_lag"`           // KAFKA_MAX_LAG (이보다 뒤처지면 not ready, 0이면 확인 안 함)
+		// Consumers 는 구독할 토픽 → 핸들러 이름 매핑이다. (예: payment-succeeded: payment_succeeded)
+		Consumers map[string]string `mapstructure:"consumers"`
+		Retry     KafkaRetry        `mapstructure:"retry"`
+		TLS       KafkaTLS          `mapstructure:"tls"`
+		SASL      KafkaSASL         `mapstructure:"sasl"`
+	}
+
+	KafkaRetry struct {
+		MaxAttempts    int           `mapstructure:"max_attempts"`    // KAFKA_RETRY_MAX_ATTEMPTS
+		InitialBackoff time.Duration `mapstructure:"initial_backoff"` // KAFKA_RETRY_INITIAL_BACKOFF
+		MaxBackoff     time.Duration `mapstructure:"max_backoff"`     // KAFKA_RETRY_MAX_BACKOFF
 	}
+
+	KafkaTLS struct {
+		Enabled            bool   `mapstructure:"enabled"`              // KAFKA_TLS_ENABLED
+		CAFile             string `mapstructure:"ca_file"`              // KAFKA_TLS_CA_FILE
+		CertFile           string `mapstructure:"cert_file"`            // KAFKA_TLS_CERT_FILE
+		KeyFile            string `mapstructure:"key_file"`             // KAFKA_TLS_KEY_FILE
+		InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"` // KAFKA_TLS_INSECURE_SKIP_VERIFY
+	}
+
+	KafkaSASL struct {
+		Mechanism string `mapstructure:"mechanism"` // KAFKA_SASL_MECHANISM ("", plain, scram-sha-256, scram-sha-512)
+		Username  string `mapstructure:"username"`  // KAFKA_SASL_USERNAME
+		Password  string `mapstructure:"password"`  // KAFKA_SASL_PASSWORD
+	}
+)
+
+// database.driver 값
+const (
+	DriverSQL     = "sql"
+	DriverPgxPool = "pgxpool"
 )
 
+// _defaults 는 설정 파일에 없을 때 쓰는 값이다. viper 는 아는 키만 AutomaticEnv 로 덮어쓰므로
+// 필수 값도 빈 값으로 등록해 두어야 파일에 없는 키를 환경변수로 줄 수 있다.
+// kafka.consumers 는 맵이라 기본값을 두면 파일의 목록과 합쳐지므로 넣지 않는다.
+var _defaults = map[string]any{
+	"app.log_level":             "info",
+	"app.host":                  "0.0.0.0",
+	"app.port":                  8083,
+	"app.payment_methods":       []string{},
+	"app.health_port":           8084,
+	"app.health_check_interval": 10 * time.Second,
+	"app.health_check_timeout":  2 * time.Second,
+
+	"database.host":                   "",
+	"database.port":                   5432,
+	"database.user":                   "",
+	"database.password":               "",
+	"database.database_name":          "",
+	"database.schema_name":            "orders",
+	"database.ssl_mode":               "disable",
+	"database.driver":                 DriverSQL,
+	"database.conn_attempts":          5,
+	"database.conn_timeout":           2 * time.Second,
+	"database.max_open_conns":         20,
+	"database.max_idle_conns":         5,
+	"database.conn_max_lifetime":      30 * time.Minute,
+	"database.tx_retries":             3,
+	"database.replica_hosts":          []string{},
+	"database.replica_check_interval": 5 * time.Second,
+
+	"kafka.brokers":                  []string{"kafka:9092"},
+	"kafka.group_id":                 "order-group",
+	"kafka.dead_letter_topic":        "order-dead-letter",
+	"kafka.commit_interval":          time.Second,
+	"kafka.max_lag":                  10000,
+	"kafka.retry.max_attempts":       5,
+	"kafka.retry.initial_backoff":    200 * time.Millisecond,
+	"kafka.retry.max_backoff":        10 * time.Second,
+	"kafka.tls.enabled":              false,
+	"kafka.tls.ca_file":              "",
+	"kafka.tls.cert_file":            "",
+	"kafka.tls.key_file":             "",
+	"kafka.tls.insecure_skip_verify": false,
+	"kafka.sasl.mechanism":           "",
+	"kafka.sasl.username":            "",
+	"kafka.sasl.password":            "",
+}
+
+// New 는 path 의 설정 파일을 읽고 환경변수로 덮어쓴 뒤 Validate 까지 통과한 설정을 반환한다.
+// 파일에 없는 키는 _defaults 를 쓴다.
+// 모든 키는 <KEY>_FILE 환경변수(예: DATABASE_PASSWORD_FILE)로 파일에서 읽어올 수도 있다.
 func New(path string) (*Config, error) {
 	vp := viper.New()
 	vp.SetConfigFile(path)
+	for key, value := range _defaults {
+		vp.SetDefault(key, value)
+	}
+	// database.host → DATABASE_HOST
+	vp.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	vp.AutomaticEnv()
 
-	dir, err := os.Getwd()
-	if err != nil {
-		slog.Error("App: get current directory error", "error", err)
-		os.Exit(1)
+	if dir, err := os.Getwd(); err == nil {
+		slog.Info("App: current directory", "dir", dir)
 	}
-	slog.Info("App: current directory", "dir", dir)
 
 	if err := vp.ReadInConfig(); err != nil {
 		return nil, err
 	}
+	if err := loadFileEnv(vp); err != nil {
+		return nil, err
+	}
 	var cfg Config
 	if err := vp.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
+
+// loadFileEnv 는 <KEY>_FILE 환경변수가 가리키는 파일 내용으로 해당 키를 덮어쓴다.
+// 쿠버네티스 secret 처럼 파일로 마운트된 값을 쓰기 위함이다.
+func loadFileEnv(vp *viper.Viper) error {
+	for _, key := range vp.AllKeys() {
+		env := strings.ToUpper(strings.ReplaceAll(key, ".", "_")) + "_FILE"
+		path, ok := os.LookupEnv(env)
+		if !ok || path == "" {
+			continue
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", env, err)
+		}
+		vp.Set(key, strings.TrimRight(string(b), "\r\n"))
+	}
+	return nil
+}
+
+// ReplicaAddr 는 replica_hosts 항목을 host 와 port 로 나눈다. 포트가 없으면 primary 의 Port 를 쓴다.
+func (d Database) ReplicaAddr(hostport string) (string, int, error) {
+	hostport = strings.TrimSpace(hostport)
+	if hostport == "" {
+		return "", 0, errors.New("must not be empty")
+	}
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		// 포트 없이 host 만 적은 경우
+		return hostport, d.Port, nil
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port >= 65536 {
+		return "", 0, fmt.Errorf("invalid port in %q", hostport)
+	}
+	return host, port, nil
+}
+
+// Addr 는 gRPC 서버가 listen 할 주소다.
+func (a App) Addr() string {
+	return net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
+}
+
+// HealthAddr 는 HTTP health 서버가 listen 할 주소다. HealthPort 가 0 이면 빈 문자열이다.
+func (a App) HealthAddr() string {
+	if a.HealthPort == 0 {
+		return ""
+	}
+	return net.JoinHostPort(a.Host, strconv.Itoa(a.HealthPort))
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// _sampleConfig 는 저장소에 들어있는 기본 설정 파일이다.
+const _sampleConfig = "../config.yaml"
+
+func TestNewEnvOverride(t *testing.T) {
+	t.Setenv("APP_HOST", "127.0.0.1")
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("KAFKA_BROKERS", "k1:9092,k2:9092")
+	t.Setenv("KAFKA_GROUP_ID", "order-test")
+
+	cfg, err := New(_sampleConfig)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got, want := cfg.App.Addr(), "127.0.0.1:9090"; got != want {
+		t.Errorf("App.Addr() = %q, want %q", got, want)
+	}
+	if got, want := cfg.Kafka.Brokers, []string{"k1:9092", "k2:9092"}; !slices.Equal(got, want) {
+		t.Errorf("Kafka.Brokers = %v, want %v", got, want)
+	}
+	if cfg.Kafka.GroupID != "order-test" {
+		t.Errorf("Kafka.GroupID = %q, want order-test", cfg.Kafka.GroupID)
+	}
+	// 파일에만 있는 값은 그대로 남는다.
+	if cfg.Kafka.Consumers["payment-succeeded"] != "payment_succeeded" {
+		t.Errorf("Kafka.Consumers = %v", cfg.Kafka.Consumers)
+	}
+}
+
+func TestAppAddr(t *testing.T) {
+	tests := []struct {
+		app        App
+		addr       string
+		healthAddr string
+	}{
+		{App{Host: "0.0.0.0", Port: 8083, HealthPort: 8084}, "0.0.0.0:8083", "0.0.0.0:8084"},
+		{App{Host: "", Port: 8083}, ":8083", ""},
+		{App{Host: "::1", Port: 8083, HealthPort: 8084}, "[::1]:8083", "[::1]:8084"},
+	}
+	for _, tt := range tests {
+		if got := tt.app.Addr(); got != tt.addr {
+			t.Errorf("%+v.Addr() = %q, want %q", tt.app, got, tt.addr)
+		}
+		if got := tt.app.HealthAddr(); got != tt.healthAddr {
+			t.Errorf("%+v.HealthAddr() = %q, want %q", tt.app, got, tt.healthAddr)
+		}
+	}
+}
+
+func TestReplicaAddr(t *testing.T) {
+	db := Database{Port: 5432}
+	tests := []struct {
+		in      string
+		host    string
+		port    int
+		wantErr bool
+	}{
+		{"replica-1", "replica-1", 5432, false},
+		{" replica-1:6432 ", "replica-1", 6432, false},
+		{"replica-1:0", "", 0, true},
+		{"replica-1:abc", "", 0, true},
+		{"", "", 0, true},
+	}
+	for _, tt := range tests {
+		host, port, err := db.ReplicaAddr(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ReplicaAddr(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if host != tt.host || port != tt.port {
+			t.Errorf("ReplicaAddr(%q) = %q, %d, want %q, %d", tt.in, host, port, tt.host, tt.port)
+		}
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	// 필수 값과 consumers 만 있는 설정 파일
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+database:
+  user: "order"
+  password: "secret"
+  database_name: "escape"
+kafka:
+  consumers:
+    payment-succeeded: "payment_succeeded"
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 파일에 없는 키도 환경변수로 줄 수 있다.
+	t.Setenv("DATABASE_HOST", "db")
+	t.Setenv("DATABASE_CONN_ATTEMPTS", "7")
+	t.Setenv("KAFKA_RETRY_MAX_BACKOFF", "30s")
+
+	cfg, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if cfg.Database.Host != "db" {
+		t.Errorf("Database.Host = %q, want db", cfg.Database.Host)
+	}
+	if cfg.Database.ConnAttempts != 7 {
+		t.Errorf("Database.ConnAttempts = %d, want 7", cfg.Database.ConnAttempts)
+	}
+	if cfg.Kafka.Retry.MaxBackoff != 30*time.Second {
+		t.Errorf("Kafka.Retry.MaxBackoff = %v, want 30s", cfg.Kafka.Retry.MaxBackoff)
+	}
+	if cfg.Database.Driver != DriverSQL || cfg.Database.ConnTimeout != 2*time.Second || cfg.Database.TxRetries != 3 {
+		t.Errorf("Database defaults = driver %q, conn_timeout %v, tx_retries %d", cfg.Database.Driver, cfg.Database.ConnTimeout, cfg.Database.TxRetries)
+	}
+	if cfg.App.HealthCheckInterval != 10*time.Second || cfg.Kafka.Retry.MaxAttempts != 5 {
+		t.Errorf("defaults = health_check_interval %v, retry.max_attempts %d", cfg.App.HealthCheckInterval, cfg.Kafka.Retry.MaxAttempts)
+	}
+	// consumers 는 기본값과 합쳐지지 않는다.
+	if len(cfg.Kafka.Consumers) != 1 {
+		t.Errorf("Kafka.Consumers = %v, want only payment-succeeded", cfg.Kafka.Consumers)
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+var (
+	validLogLevels     = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	validSSLModes      = map[string]bool{"disable": true, "allow": true, "prefer": true, "require": true, "verify-ca": true, "verify-full": true}
+	validSASLMechanism = map[string]bool{"": true, "plain": true, "scram-sha-256": true, "scram-sha-512": true}
+	validDBDrivers     = map[string]bool{DriverSQL: true, DriverPgxPool: true}
+)
+
+// Validate 는 모든 누락/잘못된 필드를 모아 한 번에 반환한다.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, field, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validLogLevels[strings.ToLower(c.App.LogLevel)], "app.log_level", "must be one of debug, info, warn, error (got %q)", c.App.LogLevel)
+	check(c.App.Port > 0 && c.App.Port < 65536, "app.port", "must be between 1 and 65535 (got %d)", c.App.Port)
+	check(c.App.HealthPort >= 0 && c.App.HealthPort < 65536, "app.health_port", "must be between 0 and 65535 (got %d)", c.App.HealthPort)
+	check(c.App.HealthPort == 0 || c.App.HealthPort != c.App.Port, "app.health_port", "must differ from app.port")
+	check(c.App.HealthCheckInterval > 0, "app.health_check_interval", "must be positive")
+	check(c.App.HealthCheckTimeout > 0, "app.health_check_timeout", "must be positive")
+	for i, m := range c.App.PaymentMethods {
+		check(strings.TrimSpace(m) != "", fmt.Sprintf("app.payment_methods[%d]", i), "must not be empty")
+	}
+
+	check(c.Database.Host != "", "database.host", "is required")
+	check(c.Database.Port > 0 && c.Database.Port < 65536, "database.port", "must be between 1 and 65535 (got %d)", c.Database.Port)
+	check(c.Database.User != "", "database.user", "is required")
+	check(c.Database.Password != "", "database.password", "is required (or set DATABASE_PASSWORD_FILE)")
+	check(c.Database.DataBaseName != "", "database.database_name", "is required")
+	check(c.Database.SchemaName != "", "database.schema_name", "is required")
+	check(validSSLModes[c.Database.SSLMode], "database.ssl_mode", "invalid value %q", c.Database.SSLMode)
+	check(validDBDrivers[c.Database.Driver], "database.driver", "must be one of sql, pgxpool (got %q)", c.Database.Driver)
+	check(c.Database.ConnAttempts > 0, "database.conn_attempts", "must be positive")
+	check(c.Database.ConnTimeout > 0, "database.conn_timeout", "must be positive")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns", "must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns", "must not be negative")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime", "must not be negative")
+	check(c.Database.TxRetries >= 0, "database.tx_retries", "must not be negative")
+	for i, h := range c.Database.ReplicaHosts {
+		_, _, err := c.Database.ReplicaAddr(h)
+		check(err == nil, fmt.Sprintf("database.replica_hosts[%d]", i), "%v", err)
+	}
+	if len(c.Database.ReplicaHosts) > 0 {
+		check(c.Database.ReplicaCheckInterval > 0, "database.replica_check_interval", "must be positive when replicas are set")
+	}
+
+	check(len(c.Kafka.Brokers) > 0, "kafka.brokers", "at least one broker is required")
+	for i, b := range c.Kafka.Brokers {
+		check(strings.TrimSpace(b) != "", fmt.Sprintf("kafka.brokers[%d]", i), "must not be empty")
+	}
+	check(c.Kafka.GroupID != "", "kafka.group_id", "is required")
+	check(c.Kafka.DeadLetterTopic != "", "kafka.dead_letter_topic", "is required")
+	check(c.Kafka.CommitInterval >= 0, "kafka.commit_interval", "must not be negative")
+	check(c.Kafka.MaxLag >= 0, "kafka.max_lag", "must not be negative")
+	check(len(c.Kafka.Consumers) > 0, "kafka.consumers", "at least one topic is required")
+	check(c.Kafka.Retry.MaxAttempts > 0, "kafka.retry.max_attempts", "must be positive")
+	check(c.Kafka.Retry.InitialBackoff > 0, "kafka.retry.initial_backoff", "must be positive")
+	check(c.Kafka.Retry.MaxBackoff >= c.Kafka.Retry.InitialBackoff, "kafka.retry.max_backoff", "must not be less than initial_backoff")
+	check((c.Kafka.TLS.CertFile == "") == (c.Kafka.TLS.KeyFile == ""), "kafka.tls", "cert_file and key_file must be set together")
+
+	mech := strings.ToLower(c.Kafka.SASL.Mechanism)
+	check(validSASLMechanism[mech], "kafka.sasl.mechanism", "invalid value %q", c.Kafka.SASL.Mechanism)
+	if mech != "" {
+		check(c.Kafka.SASL.Username != "", "kafka.sasl.username", "is required when mechanism is set")
+		check(c.Kafka.SASL.Password != "", "kafka.sasl.password", "is required when mechanism is set (or set KAFKA_SASL_PASSWORD_FILE)")
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+const redacted = "[REDACTED]"
+
+// redactedConfig 는 LogValue 가 자기 자신을 다시 호출하지 않도록 메소드가 없는 사본 타입이다.
+type redactedConfig Config
+
+// LogValue 는 비밀번호를 가린 설정을 로그에 남긴다. slog 로 Config 를 그대로 넘기면 된다.
+func (c Config) LogValue() slog.Value {
+	r := redactedConfig(c)
+	if r.Database.Password != "" {
+		r.Database.Password = redacted
+	}
+	if r.Kafka.SASL.Password != "" {
+		r.Kafka.SASL.Password = redacted
+	}
+	return slog.AnyValue(r)
+}
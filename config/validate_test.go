@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() Config {
+	return Config{
+		App: App{
+			LogLevel:            "info",
+			Host:                "0.0.0.0",
+			Port:                8083,
+			HealthPort:          8084,
+			HealthCheckInterval: 10 * time.Second,
+			HealthCheckTimeout:  2 * time.Second,
+		},
+		Database: Database{
+			Host:         "db",
+			Port:         5432,
+			User:         "order",
+			Password:     "db-secret",
+			DataBaseName: "escape",
+			SchemaName:   "orders",
+			SSLMode:      "disable",
+			Driver:       DriverSQL,
+			ConnAttempts: 3,
+			ConnTimeout:  time.Second,
+		},
+		Kafka: Kafka{
+			Brokers:         []string{"kafka:9092"},
+			GroupID:         "order-group",
+			DeadLetterTopic: "order-dead-letter",
+			Consumers:       map[string]string{"payment-succeeded": "payment_succeeded"},
+			Retry:           KafkaRetry{MaxAttempts: 5, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 10 * time.Second},
+			SASL:            KafkaSASL{Mechanism: "plain", Username: "order", Password: "kafka-secret"},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		fields []string // 에러 메시지에 모두 있어야 하는 필드. 비어있으면 통과해야 한다.
+	}{
+		{"valid", func(*Config) {}, nil},
+		{"log level case insensitive", func(c *Config) { c.App.LogLevel = "DEBUG" }, nil},
+		{"replica without port", func(c *Config) {
+			c.Database.ReplicaHosts, c.Database.ReplicaCheckInterval = []string{"replica-1"}, time.Second
+		}, nil},
+		{"bad port", func(c *Config) { c.App.Port = 0 }, []string{"app.port"}},
+		{"health port equals port", func(c *Config) { c.App.HealthPort = c.App.Port }, []string{"app.health_port"}},
+		{"unknown driver", func(c *Config) { c.Database.Driver = "mysql" }, []string{"database.driver"}},
+		{"replica without interval", func(c *Config) { c.Database.ReplicaHosts = []string{"replica-1"} }, []string{"database.replica_check_interval"}},
+		{"empty broker", func(c *Config) { c.Kafka.Brokers = []string{" "} }, []string{"kafka.brokers[0]"}},
+		{"backoff order", func(c *Config) { c.Kafka.Retry.MaxBackoff = time.Millisecond }, []string{"kafka.retry.max_backoff"}},
+		{"tls cert without key", func(c *Config) { c.Kafka.TLS.CertFile = "cert.pem" }, []string{"kafka.tls"}},
+		{"sasl without password", func(c *Config) { c.Kafka.SASL.Password = "" }, []string{"kafka.sasl.password"}},
+		{"empty payment method", func(c *Config) { c.App.PaymentMethods = []string{"card", ""} }, []string{"app.payment_methods[1]"}},
+		// 여러 필드가 잘못되면 한 번에 모두 보고한다.
+		{"all at once", func(c *Config) {
+			c.Database.Host = ""
+			c.Database.Password = ""
+			c.Kafka.GroupID = ""
+			c.Kafka.Consumers = nil
+		}, []string{"database.host", "database.password", "kafka.group_id", "kafka.consumers"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want errors for %v", tt.fields)
+			}
+			for _, f := range tt.fields {
+				if !strings.Contains(err.Error(), f+":") {
+					t.Errorf("Validate() error does not mention %s:\n%v", f, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFileEnv(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "password")
+	if err := os.WriteFile(secret, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DATABASE_PASSWORD_FILE", secret)
+
+	cfg, err := New(_sampleConfig)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	// 끝의 개행은 지운다.
+	if cfg.Database.Password != "from-file" {
+		t.Errorf("Database.Password = %q, want from-file", cfg.Database.Password)
+	}
+
+	t.Setenv("DATABASE_PASSWORD_FILE", filepath.Join(dir, "missing"))
+	if _, err := New(_sampleConfig); err == nil || !strings.Contains(err.Error(), "DATABASE_PASSWORD_FILE") {
+		t.Errorf("New() with missing secret file error = %v, want DATABASE_PASSWORD_FILE error", err)
+	}
+}
+
+func TestLogValueRedactsSecrets(t *testing.T) {
+	cfg := validConfig()
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("config", "config", cfg)
+
+	out := buf.String()
+	for _, secret := range []string{cfg.Database.Password, cfg.Kafka.SASL.Password} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output contains secret %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, redacted) {
+		t.Errorf("log output has no %s marker:\n%s", redacted, out)
+	}
+	// 원본은 바뀌지 않는다.
+	if cfg.Database.Password != "db-secret" {
+		t.Errorf("LogValue modified the config: Database.Password = %q", cfg.Database.Password)
+	}
+}
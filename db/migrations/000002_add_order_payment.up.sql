@@ -0,0 +1,6 @@
+START TRANSACTION;
+
+ALTER TABLE orders.order
+    ADD COLUMN payment_id TEXT;
+
+COMMIT;
@@ -0,0 +1,15 @@
+START TRANSACTION;
+
+CREATE TABLE orders.outbox (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX outbox_unsent_idx ON orders.outbox (id) WHERE sent_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,11 @@
+START TRANSACTION;
+
+CREATE TABLE orders.processed_messages (
+    topic TEXT NOT NULL,
+    kafka_partition INT NOT NULL,
+    kafka_offset BIGINT NOT NULL,
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (topic, kafka_partition, kafka_offset)
+);
+
+COMMIT;
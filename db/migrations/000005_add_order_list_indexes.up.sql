@@ -0,0 +1,7 @@
+START TRANSACTION;
+
+CREATE INDEX order_ordered_at_id_idx ON orders.order (ordered_at DESC, id DESC);
+CREATE INDEX order_user_id_ordered_at_id_idx ON orders.order (user_id, ordered_at DESC, id DESC);
+CREATE INDEX order_status_idx ON orders.order (status);
+
+COMMIT;
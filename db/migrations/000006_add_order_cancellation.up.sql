@@ -0,0 +1,8 @@
+START TRANSACTION;
+
+ALTER TABLE orders.order
+    ADD COLUMN cancelled_at TIMESTAMP,
+    ADD COLUMN cancelled_by TEXT,
+    ADD COLUMN cancel_reason TEXT;
+
+COMMIT;
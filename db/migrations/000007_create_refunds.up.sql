@@ -0,0 +1,26 @@
+START TRANSACTION;
+
+CREATE TABLE orders.refunds (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders.order(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    amount BIGINT NOT NULL,
+    full_refund BOOLEAN NOT NULL,
+    previous_order_status TEXT NOT NULL,
+    reason TEXT,
+    failure_reason TEXT,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX refunds_order_id_idx ON orders.refunds (order_id);
+
+CREATE TABLE orders.refund_items (
+    refund_id UUID NOT NULL REFERENCES orders.refunds(id) ON DELETE CASCADE,
+    order_item_id UUID NOT NULL REFERENCES orders.order_items(id) ON DELETE CASCADE,
+    quantity INT NOT NULL,
+    amount BIGINT NOT NULL,
+    PRIMARY KEY (refund_id, order_item_id)
+);
+
+COMMIT;
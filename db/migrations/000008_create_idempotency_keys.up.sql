@@ -0,0 +1,12 @@
+START TRANSACTION;
+
+CREATE TABLE orders.idempotency_keys (
+    user_id UUID NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    order_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+COMMIT;
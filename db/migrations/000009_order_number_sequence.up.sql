@@ -0,0 +1,17 @@
+START TRANSACTION;
+
+CREATE SEQUENCE orders.order_number_seq;
+
+-- 기존 중복 주문번호는 가장 오래된 주문만 남기고 id 접미사를 붙여 구분한다.
+UPDATE orders.order o
+SET order_number = o.order_number || '-' || left(o.id::text, 8)
+WHERE EXISTS (
+    SELECT 1 FROM orders.order d
+    WHERE d.order_number = o.order_number
+      AND d.id <> o.id
+      AND (d.ordered_at, d.id) < (o.ordered_at, o.id)
+);
+
+CREATE UNIQUE INDEX order_order_number_key ON orders.order (order_number);
+
+COMMIT;
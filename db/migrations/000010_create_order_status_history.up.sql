@@ -0,0 +1,17 @@
+START TRANSACTION;
+
+CREATE TABLE orders.order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders.order(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT,
+    source TEXT NOT NULL,
+    source_ref TEXT,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX order_status_history_order_id_idx ON orders.order_status_history (order_id, id);
+
+COMMIT;
@@ -0,0 +1,20 @@
+START TRANSACTION;
+
+ALTER TABLE orders.order
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE orders.order SET updated_at = COALESCE(paid_at, ordered_at);
+
+CREATE OR REPLACE FUNCTION orders.set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER order_set_updated_at
+    BEFORE UPDATE ON orders.order
+    FOR EACH ROW
+    EXECUTE FUNCTION orders.set_updated_at();
+
+COMMIT;
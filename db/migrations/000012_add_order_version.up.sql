@@ -0,0 +1,15 @@
+START TRANSACTION;
+
+ALTER TABLE orders.order
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+-- 모든 UPDATE 에서 updated_at 과 함께 version 을 올린다.
+CREATE OR REPLACE FUNCTION orders.set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
@@ -0,0 +1,16 @@
+START TRANSACTION;
+
+-- outbox 에 이벤트가 쌓이면 relay 가 폴링 주기를 기다리지 않고 바로 깨어나도록 알린다.
+CREATE OR REPLACE FUNCTION orders.notify_outbox() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('orders_outbox', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER outbox_notify
+    AFTER INSERT ON orders.outbox
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION orders.notify_outbox();
+
+COMMIT;
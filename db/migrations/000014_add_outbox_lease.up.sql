@@ -0,0 +1,8 @@
+START TRANSACTION;
+
+-- relay 가 발행하려고 가져간 이벤트는 locked_until 까지 다른 relay 가 가져가지 않는다.
+-- 발행 중에는 트랜잭션/row lock 을 잡지 않으므로, relay 가 죽으면 lease 가 끝난 뒤 다시 발행된다.
+ALTER TABLE orders.outbox
+    ADD COLUMN locked_until TIMESTAMP;
+
+COMMIT;
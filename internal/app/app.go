@@ -2,35 +2,51 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"sync"
+	"time"
 
 	pb "github.com/escape-ship/protos/gen"
 
+	"github.com/escape-ship/ordersrv/config"
+	"github.com/escape-ship/ordersrv/internal/outbox"
 	"github.com/escape-ship/ordersrv/internal/service"
 	"github.com/escape-ship/ordersrv/pkg/kafka"
 	"github.com/escape-ship/ordersrv/pkg/postgres"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type App struct {
+	cfg           config.App
 	KafkaConsumer []kafka.Consumer
 	pg            postgres.DBEngine
 	OrderService  *service.OrderController
+	outboxRelay   *outbox.Relay
+	health        *healthChecker
 	grpcServer    *grpc.Server
-	ctx           context.Context
-	cancel        context.CancelFunc
+	httpServer    *http.Server
+	// ctx 는 consumer, relay, health 확인 루프의 수명이다. Shutdown 에서 취소하고 wg 로 종료를 기다린다.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
 // App 생성자
-func NewApp(pg postgres.DBEngine, kafkaConsumer []kafka.Consumer) *App {
+func NewApp(cfg config.App, pg postgres.DBEngine, orderService *service.OrderController, kafkaConsumer []kafka.Consumer, outboxRelay *outbox.Relay) *App {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &App{
+		cfg:           cfg,
 		KafkaConsumer: kafkaConsumer,
 		pg:            pg,
-		OrderService:  service.NewOrderController(pg),
+		OrderService:  orderService,
+		outboxRelay:   outboxRelay,
+		health:        newHealthChecker(pg, kafkaConsumer, cfg.HealthCheckInterval, cfg.HealthCheckTimeout),
 		ctx:           ctx,
 		cancel:        cancel,
 	}
@@ -41,36 +57,81 @@ func (a *App) Run(ctx context.Context) error {
 	// gRPC 서버 설정
 	a.grpcServer = grpc.NewServer()
 	pb.RegisterOrderServiceServer(a.grpcServer, a.OrderService)
+	healthpb.RegisterHealthServer(a.grpcServer, a.health.server)
 	reflection.Register(a.grpcServer)
 
 	// Listener 생성
-	lis, err := net.Listen("tcp", ":8083")
+	addr := a.cfg.Addr()
+	lis, err := net.Listen("tcp", addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
+	// HTTP health 서버 (설정된 경우에만)
+	var healthLis net.Listener
+	if healthAddr := a.cfg.HealthAddr(); healthAddr != "" {
+		healthLis, err = net.Listen("tcp", healthAddr)
+		if err != nil {
+			lis.Close()
+			return fmt.Errorf("failed to listen for health: %w", err)
+		}
+		a.httpServer = &http.Server{Handler: a.health.httpHandler(), ReadHeaderTimeout: 5 * time.Second}
+	}
+
+	// 의존성 상태 확인을 goroutine으로 실행
+	a.goLoop(a.health.run)
+
 	// Kafka consumer를 goroutine으로 실행
 	for _, consumer := range a.KafkaConsumer {
-		go consumer.Consume(ctx)
+		a.goLoop(consumer.Consume)
+	}
+
+	// Outbox relay를 goroutine으로 실행
+	if a.outboxRelay != nil {
+		a.goLoop(a.outboxRelay.Run)
 	}
 
 	// gRPC 서버를 goroutine으로 실행
 	go func() {
-		log.Println("gRPC server listening on :8083")
+		log.Printf("gRPC server listening on %s", addr)
 		if err := a.grpcServer.Serve(lis); err != nil {
 			log.Printf("gRPC server error: %v", err)
 		}
 	}()
 
+	if a.httpServer != nil {
+		go func() {
+			log.Printf("HTTP health server listening on %s", healthLis.Addr())
+			if err := a.httpServer.Serve(healthLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("HTTP health server error: %v", err)
+			}
+		}()
+	}
+
 	// Context 완료 대기
-	<-ctx.Done()
+	select {
+	case <-ctx.Done():
+	case <-a.ctx.Done():
+	}
 	return nil
 }
 
+// goLoop 는 a.ctx 로 fn 을 goroutine 에서 실행하고 Shutdown 이 끝나기를 기다릴 수 있게 등록한다.
+func (a *App) goLoop(fn func(ctx context.Context)) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		fn(a.ctx)
+	}()
+}
+
 // Shutdown 메소드: graceful shutdown 수행
 func (a *App) Shutdown() {
 	log.Println("App: Starting graceful shutdown sequence")
 
+	// 0. readiness 를 먼저 NOT_SERVING 으로 바꿔서 새 트래픽이 들어오지 않게 한다.
+	a.health.markShuttingDown()
+
 	// 1. gRPC 서버 graceful stop
 	if a.grpcServer != nil {
 		log.Println("App: Stopping gRPC server")
@@ -78,9 +139,32 @@ func (a *App) Shutdown() {
 		log.Println("App: gRPC server stopped")
 	}
 
-	// 4. Context 취소
-	if a.cancel != nil {
-		a.cancel()
+	// 2. Context 취소 후 consumer(처리 중인 메시지 포함), relay, health 루프가 끝날 때까지 대기
+	a.cancel()
+	a.wg.Wait()
+	log.Println("App: background loops stopped")
+
+	// 3. Kafka consumer 종료 (대기 중인 offset 커밋 flush)
+	for _, consumer := range a.KafkaConsumer {
+		if err := consumer.Close(); err != nil {
+			log.Printf("App: kafka consumer close error: %v", err)
+		}
+	}
+
+	// 4. Outbox publisher 종료
+	if a.outboxRelay != nil {
+		if err := a.outboxRelay.Close(); err != nil {
+			log.Printf("App: outbox publisher close error: %v", err)
+		}
+	}
+
+	// 5. HTTP health 서버 종료 (종료 중에도 /readyz 가 응답하도록 마지막에 닫는다)
+	if a.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.httpServer.Shutdown(ctx); err != nil {
+			log.Printf("App: HTTP health server shutdown error: %v", err)
+		}
 	}
 
 	log.Println("App: Graceful shutdown sequence completed")
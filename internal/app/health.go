@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/escape-ship/ordersrv/pkg/kafka"
+	"github.com/escape-ship/ordersrv/pkg/postgres"
+	pb "github.com/escape-ship/protos/gen"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	_defaultHealthInterval = 10 * time.Second
+	_defaultHealthTimeout  = 2 * time.Second
+)
+
+// healthChecker 는 의존성(postgres, kafka consumer)을 주기적으로 확인해서
+// grpc.health.v1 서버와 HTTP /readyz 에 반영한다.
+// 의존성별 서비스 이름은 "postgres", "kafka/<topic>" 이고,
+// "" 와 OrderService 이름은 모든 의존성이 정상일 때만 SERVING 이다.
+type healthChecker struct {
+	server   *health.Server
+	checks   map[string]func(context.Context) error
+	interval time.Duration
+	timeout  time.Duration
+
+	mu       sync.Mutex
+	results  map[string]string // 의존성 → "ok" 또는 에러 메시지
+	ready    atomic.Bool
+	shutdown atomic.Bool
+}
+
+func newHealthChecker(pg postgres.DBEngine, consumers []kafka.Consumer, interval, timeout time.Duration) *healthChecker {
+	if interval <= 0 {
+		interval = _defaultHealthInterval
+	}
+	if timeout <= 0 {
+		timeout = _defaultHealthTimeout
+	}
+	h := &healthChecker{
+		server:   health.NewServer(),
+		checks:   map[string]func(context.Context) error{"postgres": pg.Ping},
+		interval: interval,
+		timeout:  timeout,
+		results:  map[string]string{},
+	}
+	for _, c := range consumers {
+		h.checks["kafka/"+c.Topic()] = c.Check
+	}
+	// 첫 확인 전까지는 준비되지 않은 상태다.
+	for _, name := range h.serviceNames() {
+		h.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return h
+}
+
+// run 은 ctx 가 끝날 때까지 interval 마다 모든 의존성을 확인한다.
+func (h *healthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *healthChecker) checkAll(ctx context.Context) {
+	type result struct {
+		name string
+		err  error
+	}
+	ch := make(chan result, len(h.checks))
+	for name, check := range h.checks {
+		go func() {
+			cctx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+			ch <- result{name, check(cctx)}
+		}()
+	}
+
+	allOK := true
+	results := make(map[string]string, len(h.checks))
+	for range h.checks {
+		r := <-ch
+		status := healthpb.HealthCheckResponse_SERVING
+		results[r.name] = "ok"
+		if r.err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			results[r.name] = r.err.Error()
+			allOK = false
+			log.Printf("Health: %s check failed: %v", r.name, r.err)
+		}
+		h.server.SetServingStatus(r.name, status)
+	}
+
+	h.mu.Lock()
+	h.results = results
+	h.mu.Unlock()
+
+	if h.shutdown.Load() {
+		return
+	}
+	h.ready.Store(allOK)
+	overall := healthpb.HealthCheckResponse_NOT_SERVING
+	if allOK {
+		overall = healthpb.HealthCheckResponse_SERVING
+	}
+	h.server.SetServingStatus("", overall)
+	h.server.SetServingStatus(pb.OrderService_ServiceDesc.ServiceName, overall)
+}
+
+// markShuttingDown 은 모든 서비스를 NOT_SERVING 으로 바꾸고 이후 확인 결과를 무시한다.
+func (h *healthChecker) markShuttingDown() {
+	h.shutdown.Store(true)
+	h.ready.Store(false)
+	h.server.Shutdown()
+}
+
+func (h *healthChecker) serviceNames() []string {
+	names := []string{"", pb.OrderService_ServiceDesc.ServiceName}
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	return names
+}
+
+// httpHandler 는 오케스트레이터용 /healthz(프로세스 생존), /readyz(트래픽 수신 가능) 핸들러다.
+func (h *healthChecker) httpHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		checks := make(map[string]string, len(h.results))
+		for name, res := range h.results {
+			checks[name] = res
+		}
+		h.mu.Unlock()
+
+		status, code := "ready", http.StatusOK
+		switch {
+		case h.shutdown.Load():
+			status, code = "shutting down", http.StatusServiceUnavailable
+		case !h.ready.Load():
+			status, code = "not ready", http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(struct {
+			Status string            `json:"status"`
+			Checks map[string]string `json:"checks"`
+		}{status, checks})
+	})
+	return mux
+}
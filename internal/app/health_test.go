@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/escape-ship/ordersrv/pkg/kafka"
+	"github.com/escape-ship/ordersrv/pkg/postgres"
+	pb "github.com/escape-ship/protos/gen"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakeDB 는 Ping 만 구현한 DBEngine 이다. 다른 메서드를 부르면 panic 한다.
+type fakeDB struct {
+	postgres.DBEngine
+	err error
+}
+
+func (d fakeDB) Ping(context.Context) error { return d.err }
+
+// fakeConsumer 는 Topic 과 Check 만 구현한 Consumer 다.
+type fakeConsumer struct {
+	kafka.Consumer
+	topic string
+	err   error
+}
+
+func (c fakeConsumer) Topic() string               { return c.topic }
+func (c fakeConsumer) Check(context.Context) error { return c.err }
+
+type readyzBody struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func getReadyz(t *testing.T, h *healthChecker) (int, readyzBody) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.httpHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body readyzBody
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode /readyz body: %v", err)
+	}
+	return rec.Code, body
+}
+
+func grpcStatus(t *testing.T, h *healthChecker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q) error = %v", service, err)
+	}
+	return resp.Status
+}
+
+func TestHealthCheckAll(t *testing.T) {
+	errDown := errors.New("connection refused")
+	serving, notServing := healthpb.HealthCheckResponse_SERVING, healthpb.HealthCheckResponse_NOT_SERVING
+	tests := []struct {
+		name     string
+		pgErr    error
+		kafkaErr error
+		code     int
+		overall  healthpb.HealthCheckResponse_ServingStatus
+		checks   map[string]string
+	}{
+		{"all ok", nil, nil, http.StatusOK, serving, map[string]string{"postgres": "ok", "kafka/orders": "ok"}},
+		// 의존성 하나만 실패해도 전체가 NOT_SERVING 이다.
+		{"postgres down", errDown, nil, http.StatusServiceUnavailable, notServing, map[string]string{"postgres": errDown.Error(), "kafka/orders": "ok"}},
+		{"kafka down", nil, errDown, http.StatusServiceUnavailable, notServing, map[string]string{"postgres": "ok", "kafka/orders": errDown.Error()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHealthChecker(fakeDB{err: tt.pgErr}, []kafka.Consumer{fakeConsumer{topic: "orders", err: tt.kafkaErr}}, time.Second, time.Second)
+
+			// 첫 확인 전에는 준비되지 않은 상태다.
+			if code, body := getReadyz(t, h); code != http.StatusServiceUnavailable || body.Status != "not ready" {
+				t.Fatalf("/readyz before first check = %d %q, want 503 not ready", code, body.Status)
+			}
+			if got := grpcStatus(t, h, ""); got != notServing {
+				t.Fatalf("overall status before first check = %v, want NOT_SERVING", got)
+			}
+
+			h.checkAll(context.Background())
+
+			code, body := getReadyz(t, h)
+			if code != tt.code {
+				t.Errorf("/readyz code = %d, want %d", code, tt.code)
+			}
+			if len(body.Checks) != len(tt.checks) {
+				t.Errorf("/readyz checks = %v, want %v", body.Checks, tt.checks)
+			}
+			for name, want := range tt.checks {
+				if body.Checks[name] != want {
+					t.Errorf("/readyz checks[%q] = %q, want %q", name, body.Checks[name], want)
+				}
+				status := serving
+				if want != "ok" {
+					status = notServing
+				}
+				if got := grpcStatus(t, h, name); got != status {
+					t.Errorf("status of %q = %v, want %v", name, got, status)
+				}
+			}
+			for _, service := range []string{"", pb.OrderService_ServiceDesc.ServiceName} {
+				if got := grpcStatus(t, h, service); got != tt.overall {
+					t.Errorf("status of %q = %v, want %v", service, got, tt.overall)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthMarkShuttingDown(t *testing.T) {
+	h := newHealthChecker(fakeDB{}, []kafka.Consumer{fakeConsumer{topic: "orders"}}, time.Second, time.Second)
+	h.checkAll(context.Background())
+	if code, _ := getReadyz(t, h); code != http.StatusOK {
+		t.Fatalf("/readyz code = %d, want 200", code)
+	}
+
+	h.markShuttingDown()
+	// 종료 후에는 확인이 성공해도 다시 준비 상태가 되지 않는다.
+	h.checkAll(context.Background())
+
+	code, body := getReadyz(t, h)
+	if code != http.StatusServiceUnavailable || body.Status != "shutting down" {
+		t.Errorf("/readyz = %d %q, want 503 shutting down", code, body.Status)
+	}
+	for _, service := range []string{"", pb.OrderService_ServiceDesc.ServiceName, "postgres", "kafka/orders"} {
+		if got := grpcStatus(t, h, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("status of %q = %v, want NOT_SERVING", service, got)
+		}
+	}
+
+	// 프로세스는 살아있다.
+	rec := httptest.NewRecorder()
+	h.httpHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/healthz code = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: batch.go
+
+package pgxdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrBatchAlreadyClosed = errors.New("batch already closed")
+)
+
+const markOutboxEventsSent = `-- name: MarkOutboxEventsSent :batchexec
+UPDATE orders.outbox
+SET sent_at = NOW()
+WHERE id = $1
+`
+
+type MarkOutboxEventsSentBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) MarkOutboxEventsSent(ctx context.Context, id []int64) *MarkOutboxEventsSentBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range id {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(markOutboxEventsSent, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &MarkOutboxEventsSentBatchResults{br, len(id), false}
+}
+
+func (b *MarkOutboxEventsSentBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *MarkOutboxEventsSentBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
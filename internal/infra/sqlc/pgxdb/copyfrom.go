@@ -0,0 +1,49 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: copyfrom.go
+
+package pgxdb
+
+import (
+	"context"
+)
+
+// iteratorForCopyOrderItems implements pgx.CopyFromSource.
+type iteratorForCopyOrderItems struct {
+	rows                 []CopyOrderItemsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCopyOrderItems) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCopyOrderItems) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].OrderID,
+		r.rows[0].ProductID,
+		r.rows[0].ProductName,
+		r.rows[0].ProductPrice,
+		r.rows[0].ProductOptions,
+		r.rows[0].Quantity,
+	}, nil
+}
+
+func (r iteratorForCopyOrderItems) Err() error {
+	return nil
+}
+
+// pgx 전용 쿼리 (COPY, batch). database/sql 패키지에는 생성되지 않는다.
+func (q *Queries) CopyOrderItems(ctx context.Context, arg []CopyOrderItemsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"orders", "order_items"}, []string{"id", "order_id", "product_id", "product_name", "product_price", "product_options", "quantity"}, &iteratorForCopyOrderItems{rows: arg})
+}
@@ -0,0 +1,98 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+
+package pgxdb
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type OrdersIdempotencyKey struct {
+	UserID         pgtype.UUID      `json:"user_id"`
+	IdempotencyKey string           `json:"idempotency_key"`
+	Fingerprint    string           `json:"fingerprint"`
+	OrderID        pgtype.UUID      `json:"order_id"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+}
+
+type OrdersOrder struct {
+	ID              pgtype.UUID      `json:"id"`
+	UserID          pgtype.UUID      `json:"user_id"`
+	OrderNumber     string           `json:"order_number"`
+	Status          string           `json:"status"`
+	TotalPrice      int64            `json:"total_price"`
+	Quantity        int32            `json:"quantity"`
+	PaymentMethod   string           `json:"payment_method"`
+	ShippingFee     int32            `json:"shipping_fee"`
+	ShippingAddress string           `json:"shipping_address"`
+	OrderedAt       pgtype.Timestamp `json:"ordered_at"`
+	PaidAt          pgtype.Timestamp `json:"paid_at"`
+	Memo            pgtype.Text      `json:"memo"`
+	PaymentID       pgtype.Text      `json:"payment_id"`
+	CancelledAt     pgtype.Timestamp `json:"cancelled_at"`
+	CancelledBy     pgtype.Text      `json:"cancelled_by"`
+	CancelReason    pgtype.Text      `json:"cancel_reason"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	Version         int32            `json:"version"`
+}
+
+type OrdersOrderItem struct {
+	ID             pgtype.UUID `json:"id"`
+	OrderID        pgtype.UUID `json:"order_id"`
+	ProductID      pgtype.UUID `json:"product_id"`
+	ProductName    string      `json:"product_name"`
+	ProductPrice   int64       `json:"product_price"`
+	ProductOptions []byte      `json:"product_options"`
+	Quantity       int32       `json:"quantity"`
+}
+
+type OrdersOrderStatusHistory struct {
+	ID         int64            `json:"id"`
+	OrderID    pgtype.UUID      `json:"order_id"`
+	FromStatus pgtype.Text      `json:"from_status"`
+	ToStatus   string           `json:"to_status"`
+	Actor      string           `json:"actor"`
+	Reason     pgtype.Text      `json:"reason"`
+	Source     string           `json:"source"`
+	SourceRef  pgtype.Text      `json:"source_ref"`
+	ChangedAt  pgtype.Timestamp `json:"changed_at"`
+}
+
+type OrdersOutbox struct {
+	ID          int64            `json:"id"`
+	AggregateID pgtype.UUID      `json:"aggregate_id"`
+	EventType   string           `json:"event_type"`
+	Topic       string           `json:"topic"`
+	Payload     []byte           `json:"payload"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	SentAt      pgtype.Timestamp `json:"sent_at"`
+	LockedUntil pgtype.Timestamp `json:"locked_until"`
+}
+
+type OrdersProcessedMessage struct {
+	Topic          string           `json:"topic"`
+	KafkaPartition int32            `json:"kafka_partition"`
+	KafkaOffset    int64            `json:"kafka_offset"`
+	ProcessedAt    pgtype.Timestamp `json:"processed_at"`
+}
+
+type OrdersRefund struct {
+	ID                  pgtype.UUID      `json:"id"`
+	OrderID             pgtype.UUID      `json:"order_id"`
+	Status              string           `json:"status"`
+	Amount              int64            `json:"amount"`
+	FullRefund          bool             `json:"full_refund"`
+	PreviousOrderStatus string           `json:"previous_order_status"`
+	Reason              pgtype.Text      `json:"reason"`
+	FailureReason       pgtype.Text      `json:"failure_reason"`
+	RequestedAt         pgtype.Timestamp `json:"requested_at"`
+	CompletedAt         pgtype.Timestamp `json:"completed_at"`
+}
+
+type OrdersRefundItem struct {
+	RefundID    pgtype.UUID `json:"refund_id"`
+	OrderItemID pgtype.UUID `json:"order_item_id"`
+	Quantity    int32       `json:"quantity"`
+	Amount      int64       `json:"amount"`
+}
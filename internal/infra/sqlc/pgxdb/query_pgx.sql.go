@@ -0,0 +1,20 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: query_pgx.sql
+
+package pgxdb
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CopyOrderItemsParams struct {
+	ID             pgtype.UUID `json:"id"`
+	OrderID        pgtype.UUID `json:"order_id"`
+	ProductID      pgtype.UUID `json:"product_id"`
+	ProductName    string      `json:"product_name"`
+	ProductPrice   int64       `json:"product_price"`
+	ProductOptions []byte      `json:"product_options"`
+	Quantity       int32       `json:"quantity"`
+}
@@ -6,12 +6,21 @@ package postgresql
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+type OrdersIdempotencyKey struct {
+	UserID         uuid.UUID `json:"user_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	Fingerprint    string    `json:"fingerprint"`
+	OrderID        uuid.UUID `json:"order_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
 type OrdersOrder struct {
 	ID              uuid.UUID      `json:"id"`
 	UserID          uuid.UUID      `json:"user_id"`
@@ -25,6 +34,12 @@ type OrdersOrder struct {
 	OrderedAt       time.Time      `json:"ordered_at"`
 	PaidAt          sql.NullTime   `json:"paid_at"`
 	Memo            sql.NullString `json:"memo"`
+	PaymentID       sql.NullString `json:"payment_id"`
+	CancelledAt     sql.NullTime   `json:"cancelled_at"`
+	CancelledBy     sql.NullString `json:"cancelled_by"`
+	CancelReason    sql.NullString `json:"cancel_reason"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	Version         int32          `json:"version"`
 }
 
 type OrdersOrderItem struct {
@@ -36,3 +51,53 @@ type OrdersOrderItem struct {
 	ProductOptions pqtype.NullRawMessage `json:"product_options"`
 	Quantity       int32                 `json:"quantity"`
 }
+
+type OrdersOrderStatusHistory struct {
+	ID         int64          `json:"id"`
+	OrderID    uuid.UUID      `json:"order_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Actor      string         `json:"actor"`
+	Reason     sql.NullString `json:"reason"`
+	Source     string         `json:"source"`
+	SourceRef  sql.NullString `json:"source_ref"`
+	ChangedAt  time.Time      `json:"changed_at"`
+}
+
+type OrdersOutbox struct {
+	ID          int64           `json:"id"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	EventType   string          `json:"event_type"`
+	Topic       string          `json:"topic"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+	SentAt      sql.NullTime    `json:"sent_at"`
+	LockedUntil sql.NullTime    `json:"locked_until"`
+}
+
+type OrdersProcessedMessage struct {
+	Topic          string    `json:"topic"`
+	KafkaPartition int32     `json:"kafka_partition"`
+	KafkaOffset    int64     `json:"kafka_offset"`
+	ProcessedAt    time.Time `json:"processed_at"`
+}
+
+type OrdersRefund struct {
+	ID                  uuid.UUID      `json:"id"`
+	OrderID             uuid.UUID      `json:"order_id"`
+	Status              string         `json:"status"`
+	Amount              int64          `json:"amount"`
+	FullRefund          bool           `json:"full_refund"`
+	PreviousOrderStatus string         `json:"previous_order_status"`
+	Reason              sql.NullString `json:"reason"`
+	FailureReason       sql.NullString `json:"failure_reason"`
+	RequestedAt         time.Time      `json:"requested_at"`
+	CompletedAt         sql.NullTime   `json:"completed_at"`
+}
+
+type OrdersRefundItem struct {
+	RefundID    uuid.UUID `json:"refund_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+	Amount      int64     `json:"amount"`
+}
@@ -8,13 +8,100 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
+const cancelOrder = `-- name: CancelOrder :execrows
+UPDATE orders.order
+SET status = $2,
+    cancelled_at = NOW(),
+    cancelled_by = $3,
+    cancel_reason = $4
+WHERE id = $1
+  AND version = $5
+`
+
+type CancelOrderParams struct {
+	ID           uuid.UUID      `json:"id"`
+	Status       string         `json:"status"`
+	CancelledBy  sql.NullString `json:"cancelled_by"`
+	CancelReason sql.NullString `json:"cancel_reason"`
+	Version      int32          `json:"version"`
+}
+
+func (q *Queries) CancelOrder(ctx context.Context, arg CancelOrderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cancelOrder,
+		arg.ID,
+		arg.Status,
+		arg.CancelledBy,
+		arg.CancelReason,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE orders.outbox
+SET locked_until = NOW() + make_interval(secs => $1::float8)
+WHERE id IN (
+    SELECT o.id FROM orders.outbox o
+    WHERE o.sent_at IS NULL
+      AND (o.locked_until IS NULL OR o.locked_until < NOW())
+      AND o.topic = ANY($2::text[])
+    ORDER BY o.id
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, aggregate_id, event_type, topic, payload, created_at, sent_at, locked_until
+`
+
+type ClaimOutboxEventsParams struct {
+	LeaseSeconds float64  `json:"lease_seconds"`
+	Topics       []string `json:"topics"`
+	BatchSize    int32    `json:"batch_size"`
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OrdersOutbox, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxEvents, arg.LeaseSeconds, pq.Array(arg.Topics), arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrdersOutbox
+	for rows.Next() {
+		var i OrdersOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.AggregateID,
+			&i.EventType,
+			&i.Topic,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.SentAt,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllOrders = `-- name: GetAllOrders :many
-SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo FROM orders.order
+SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo, payment_id, cancelled_at, cancelled_by, cancel_reason, updated_at, version FROM orders.order
 `
 
 func (q *Queries) GetAllOrders(ctx context.Context) ([]OrdersOrder, error) {
@@ -39,6 +126,12 @@ func (q *Queries) GetAllOrders(ctx context.Context) ([]OrdersOrder, error) {
 			&i.OrderedAt,
 			&i.PaidAt,
 			&i.Memo,
+			&i.PaymentID,
+			&i.CancelledAt,
+			&i.CancelledBy,
+			&i.CancelReason,
+			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -53,6 +146,119 @@ func (q *Queries) GetAllOrders(ctx context.Context) ([]OrdersOrder, error) {
 	return items, nil
 }
 
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT user_id, idempotency_key, fingerprint, order_id, created_at FROM orders.idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2
+`
+
+type GetIdempotencyKeyParams struct {
+	UserID         uuid.UUID `json:"user_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+}
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (OrdersIdempotencyKey, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKey, arg.UserID, arg.IdempotencyKey)
+	var i OrdersIdempotencyKey
+	err := row.Scan(
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.Fingerprint,
+		&i.OrderID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOrder = `-- name: GetOrder :one
+SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo, payment_id, cancelled_at, cancelled_by, cancel_reason, updated_at, version FROM orders.order WHERE id = $1
+`
+
+func (q *Queries) GetOrder(ctx context.Context, id uuid.UUID) (OrdersOrder, error) {
+	row := q.db.QueryRowContext(ctx, getOrder, id)
+	var i OrdersOrder
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrderNumber,
+		&i.Status,
+		&i.TotalPrice,
+		&i.Quantity,
+		&i.PaymentMethod,
+		&i.ShippingFee,
+		&i.ShippingAddress,
+		&i.OrderedAt,
+		&i.PaidAt,
+		&i.Memo,
+		&i.PaymentID,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancelReason,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getOrderByNumber = `-- name: GetOrderByNumber :one
+SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo, payment_id, cancelled_at, cancelled_by, cancel_reason, updated_at, version FROM orders.order WHERE order_number = $1
+`
+
+func (q *Queries) GetOrderByNumber(ctx context.Context, orderNumber string) (OrdersOrder, error) {
+	row := q.db.QueryRowContext(ctx, getOrderByNumber, orderNumber)
+	var i OrdersOrder
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrderNumber,
+		&i.Status,
+		&i.TotalPrice,
+		&i.Quantity,
+		&i.PaymentMethod,
+		&i.ShippingFee,
+		&i.ShippingAddress,
+		&i.OrderedAt,
+		&i.PaidAt,
+		&i.Memo,
+		&i.PaymentID,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancelReason,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getOrderForUpdate = `-- name: GetOrderForUpdate :one
+SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo, payment_id, cancelled_at, cancelled_by, cancel_reason, updated_at, version FROM orders.order WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (OrdersOrder, error) {
+	row := q.db.QueryRowContext(ctx, getOrderForUpdate, id)
+	var i OrdersOrder
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrderNumber,
+		&i.Status,
+		&i.TotalPrice,
+		&i.Quantity,
+		&i.PaymentMethod,
+		&i.ShippingFee,
+		&i.ShippingAddress,
+		&i.OrderedAt,
+		&i.PaidAt,
+		&i.Memo,
+		&i.PaymentID,
+		&i.CancelledAt,
+		&i.CancelledBy,
+		&i.CancelReason,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
 const getOrderItems = `-- name: GetOrderItems :many
 SELECT id, order_id, product_id, product_name, product_price, product_options, quantity FROM orders.order_items WHERE order_id = $1
 `
@@ -88,28 +294,78 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID uuid.UUID) ([]Order
 	return items, nil
 }
 
-const getOrderWithItems = `-- name: GetOrderWithItems :one
-SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo FROM orders.order WHERE id = $1
+const getOrderItemsByOrderIDs = `-- name: GetOrderItemsByOrderIDs :many
+SELECT id, order_id, product_id, product_name, product_price, product_options, quantity FROM orders.order_items WHERE order_id = ANY($1::uuid[])
 `
 
-func (q *Queries) GetOrderWithItems(ctx context.Context, id uuid.UUID) (OrdersOrder, error) {
-	row := q.db.QueryRowContext(ctx, getOrderWithItems, id)
-	var i OrdersOrder
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.OrderNumber,
-		&i.Status,
-		&i.TotalPrice,
-		&i.Quantity,
-		&i.PaymentMethod,
-		&i.ShippingFee,
-		&i.ShippingAddress,
-		&i.OrderedAt,
-		&i.PaidAt,
-		&i.Memo,
-	)
-	return i, err
+func (q *Queries) GetOrderItemsByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]OrdersOrderItem, error) {
+	rows, err := q.db.QueryContext(ctx, getOrderItemsByOrderIDs, pq.Array(orderIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrdersOrderItem
+	for rows.Next() {
+		var i OrdersOrderItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.ProductPrice,
+			&i.ProductOptions,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderStatusHistory = `-- name: GetOrderStatusHistory :many
+SELECT id, order_id, from_status, to_status, actor, reason, source, source_ref, changed_at FROM orders.order_status_history
+WHERE order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetOrderStatusHistory(ctx context.Context, orderID uuid.UUID) ([]OrdersOrderStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, getOrderStatusHistory, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrdersOrderStatusHistory
+	for rows.Next() {
+		var i OrdersOrderStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Actor,
+			&i.Reason,
+			&i.Source,
+			&i.SourceRef,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getProductIDsByOrderID = `-- name: GetProductIDsByOrderID :many
@@ -141,6 +397,113 @@ func (q *Queries) GetProductIDsByOrderID(ctx context.Context, orderID uuid.UUID)
 	return items, nil
 }
 
+const getRefundAmounts = `-- name: GetRefundAmounts :one
+SELECT COALESCE(SUM(amount) FILTER (WHERE status = 'completed'), 0)::bigint AS completed,
+       COALESCE(SUM(amount) FILTER (WHERE status = 'requested'), 0)::bigint AS pending
+FROM orders.refunds
+WHERE order_id = $1
+`
+
+type GetRefundAmountsRow struct {
+	Completed int64 `json:"completed"`
+	Pending   int64 `json:"pending"`
+}
+
+func (q *Queries) GetRefundAmounts(ctx context.Context, orderID uuid.UUID) (GetRefundAmountsRow, error) {
+	row := q.db.QueryRowContext(ctx, getRefundAmounts, orderID)
+	var i GetRefundAmountsRow
+	err := row.Scan(&i.Completed, &i.Pending)
+	return i, err
+}
+
+const getRefundForUpdate = `-- name: GetRefundForUpdate :one
+SELECT id, order_id, status, amount, full_refund, previous_order_status, reason, failure_reason, requested_at, completed_at FROM orders.refunds WHERE id = $1 FOR UPDATE
+`
+
+func (q *Queries) GetRefundForUpdate(ctx context.Context, id uuid.UUID) (OrdersRefund, error) {
+	row := q.db.QueryRowContext(ctx, getRefundForUpdate, id)
+	var i OrdersRefund
+	err := row.Scan(
+		&i.ID,
+		&i.OrderID,
+		&i.Status,
+		&i.Amount,
+		&i.FullRefund,
+		&i.PreviousOrderStatus,
+		&i.Reason,
+		&i.FailureReason,
+		&i.RequestedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getRefundedItemQuantities = `-- name: GetRefundedItemQuantities :many
+SELECT ri.order_item_id, SUM(ri.quantity)::int AS quantity
+FROM orders.refund_items ri
+JOIN orders.refunds r ON r.id = ri.refund_id
+WHERE r.order_id = $1
+  AND r.status <> 'failed'
+GROUP BY ri.order_item_id
+`
+
+type GetRefundedItemQuantitiesRow struct {
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+}
+
+func (q *Queries) GetRefundedItemQuantities(ctx context.Context, orderID uuid.UUID) ([]GetRefundedItemQuantitiesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRefundedItemQuantities, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRefundedItemQuantitiesRow
+	for rows.Next() {
+		var i GetRefundedItemQuantitiesRow
+		if err := rows.Scan(&i.OrderItemID, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertIdempotencyKey = `-- name: InsertIdempotencyKey :execrows
+INSERT INTO orders.idempotency_keys (
+    user_id, idempotency_key, fingerprint, order_id
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT DO NOTHING
+`
+
+type InsertIdempotencyKeyParams struct {
+	UserID         uuid.UUID `json:"user_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	Fingerprint    string    `json:"fingerprint"`
+	OrderID        uuid.UUID `json:"order_id"`
+}
+
+func (q *Queries) InsertIdempotencyKey(ctx context.Context, arg InsertIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertIdempotencyKey,
+		arg.UserID,
+		arg.IdempotencyKey,
+		arg.Fingerprint,
+		arg.OrderID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const insertOrder = `-- name: InsertOrder :one
 INSERT INTO orders.order (
     id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo
@@ -215,19 +578,317 @@ func (q *Queries) InsertOrderItem(ctx context.Context, arg InsertOrderItemParams
 	return err
 }
 
-const updateOrderStatus = `-- name: UpdateOrderStatus :exec
+const insertOrderStatusHistory = `-- name: InsertOrderStatusHistory :exec
+INSERT INTO orders.order_status_history (
+    order_id, from_status, to_status, actor, reason, source, source_ref
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+`
+
+type InsertOrderStatusHistoryParams struct {
+	OrderID    uuid.UUID      `json:"order_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Actor      string         `json:"actor"`
+	Reason     sql.NullString `json:"reason"`
+	Source     string         `json:"source"`
+	SourceRef  sql.NullString `json:"source_ref"`
+}
+
+func (q *Queries) InsertOrderStatusHistory(ctx context.Context, arg InsertOrderStatusHistoryParams) error {
+	_, err := q.db.ExecContext(ctx, insertOrderStatusHistory,
+		arg.OrderID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Actor,
+		arg.Reason,
+		arg.Source,
+		arg.SourceRef,
+	)
+	return err
+}
+
+const insertOutboxEvent = `-- name: InsertOutboxEvent :exec
+INSERT INTO orders.outbox (
+    aggregate_id, event_type, topic, payload
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type InsertOutboxEventParams struct {
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	EventType   string          `json:"event_type"`
+	Topic       string          `json:"topic"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+func (q *Queries) InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, insertOutboxEvent,
+		arg.AggregateID,
+		arg.EventType,
+		arg.Topic,
+		arg.Payload,
+	)
+	return err
+}
+
+const insertProcessedMessage = `-- name: InsertProcessedMessage :execrows
+INSERT INTO orders.processed_messages (
+    topic, kafka_partition, kafka_offset
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT DO NOTHING
+`
+
+type InsertProcessedMessageParams struct {
+	Topic          string `json:"topic"`
+	KafkaPartition int32  `json:"kafka_partition"`
+	KafkaOffset    int64  `json:"kafka_offset"`
+}
+
+func (q *Queries) InsertProcessedMessage(ctx context.Context, arg InsertProcessedMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertProcessedMessage, arg.Topic, arg.KafkaPartition, arg.KafkaOffset)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertRefund = `-- name: InsertRefund :exec
+INSERT INTO orders.refunds (
+    id, order_id, status, amount, full_refund, previous_order_status, reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+`
+
+type InsertRefundParams struct {
+	ID                  uuid.UUID      `json:"id"`
+	OrderID             uuid.UUID      `json:"order_id"`
+	Status              string         `json:"status"`
+	Amount              int64          `json:"amount"`
+	FullRefund          bool           `json:"full_refund"`
+	PreviousOrderStatus string         `json:"previous_order_status"`
+	Reason              sql.NullString `json:"reason"`
+}
+
+func (q *Queries) InsertRefund(ctx context.Context, arg InsertRefundParams) error {
+	_, err := q.db.ExecContext(ctx, insertRefund,
+		arg.ID,
+		arg.OrderID,
+		arg.Status,
+		arg.Amount,
+		arg.FullRefund,
+		arg.PreviousOrderStatus,
+		arg.Reason,
+	)
+	return err
+}
+
+const insertRefundItem = `-- name: InsertRefundItem :exec
+INSERT INTO orders.refund_items (
+    refund_id, order_item_id, quantity, amount
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type InsertRefundItemParams struct {
+	RefundID    uuid.UUID `json:"refund_id"`
+	OrderItemID uuid.UUID `json:"order_item_id"`
+	Quantity    int32     `json:"quantity"`
+	Amount      int64     `json:"amount"`
+}
+
+func (q *Queries) InsertRefundItem(ctx context.Context, arg InsertRefundItemParams) error {
+	_, err := q.db.ExecContext(ctx, insertRefundItem,
+		arg.RefundID,
+		arg.OrderItemID,
+		arg.Quantity,
+		arg.Amount,
+	)
+	return err
+}
+
+const listOrders = `-- name: ListOrders :many
+SELECT id, user_id, order_number, status, total_price, quantity, payment_method, shipping_fee, shipping_address, ordered_at, paid_at, memo, payment_id, cancelled_at, cancelled_by, cancel_reason, updated_at, version FROM orders.order
+WHERE ($1::uuid IS NULL OR user_id = $1::uuid)
+  AND (cardinality($2::text[]) = 0 OR status = ANY($2::text[]))
+  AND ($3::timestamp IS NULL OR ordered_at >= $3::timestamp)
+  AND ($4::timestamp IS NULL OR ordered_at < $4::timestamp)
+  AND ($5::varchar IS NULL OR payment_method = $5::varchar)
+  AND ($6::timestamp IS NULL
+       OR (ordered_at, id) < ($6::timestamp, $7::uuid))
+ORDER BY ordered_at DESC, id DESC
+LIMIT $8
+`
+
+type ListOrdersParams struct {
+	UserID          uuid.NullUUID  `json:"user_id"`
+	Statuses        []string       `json:"statuses"`
+	OrderedFrom     sql.NullTime   `json:"ordered_from"`
+	OrderedTo       sql.NullTime   `json:"ordered_to"`
+	PaymentMethod   sql.NullString `json:"payment_method"`
+	CursorOrderedAt sql.NullTime   `json:"cursor_ordered_at"`
+	CursorID        uuid.NullUUID  `json:"cursor_id"`
+	PageSize        int32          `json:"page_size"`
+}
+
+func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]OrdersOrder, error) {
+	rows, err := q.db.QueryContext(ctx, listOrders,
+		arg.UserID,
+		pq.Array(arg.Statuses),
+		arg.OrderedFrom,
+		arg.OrderedTo,
+		arg.PaymentMethod,
+		arg.CursorOrderedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrdersOrder
+	for rows.Next() {
+		var i OrdersOrder
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrderNumber,
+			&i.Status,
+			&i.TotalPrice,
+			&i.Quantity,
+			&i.PaymentMethod,
+			&i.ShippingFee,
+			&i.ShippingAddress,
+			&i.OrderedAt,
+			&i.PaidAt,
+			&i.Memo,
+			&i.PaymentID,
+			&i.CancelledAt,
+			&i.CancelledBy,
+			&i.CancelReason,
+			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOrderPaid = `-- name: MarkOrderPaid :execrows
+UPDATE orders.order
+SET status = $2,
+    payment_id = $3,
+    paid_at = $4
+WHERE id = $1
+  AND version = $5
+`
+
+type MarkOrderPaidParams struct {
+	ID        uuid.UUID      `json:"id"`
+	Status    string         `json:"status"`
+	PaymentID sql.NullString `json:"payment_id"`
+	PaidAt    sql.NullTime   `json:"paid_at"`
+	Version   int32          `json:"version"`
+}
+
+func (q *Queries) MarkOrderPaid(ctx context.Context, arg MarkOrderPaidParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markOrderPaid,
+		arg.ID,
+		arg.Status,
+		arg.PaymentID,
+		arg.PaidAt,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markOutboxEventSent = `-- name: MarkOutboxEventSent :exec
+UPDATE orders.outbox
+SET sent_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventSent(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventSent, id)
+	return err
+}
+
+const nextOrderNumberSeq = `-- name: NextOrderNumberSeq :one
+SELECT nextval('orders.order_number_seq')::bigint
+`
+
+func (q *Queries) NextOrderNumberSeq(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, nextOrderNumberSeq)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const releaseOutboxEvents = `-- name: ReleaseOutboxEvents :exec
+UPDATE orders.outbox
+SET locked_until = NULL
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ReleaseOutboxEvents(ctx context.Context, ids []int64) error {
+	_, err := q.db.ExecContext(ctx, releaseOutboxEvents, pq.Array(ids))
+	return err
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :execrows
 UPDATE orders.order
 SET status = $2,
     updated_at = NOW()
 WHERE id = $1
+  AND version = $3
 `
 
 type UpdateOrderStatusParams struct {
-	ID     uuid.UUID `json:"id"`
-	Status string    `json:"status"`
+	ID      uuid.UUID `json:"id"`
+	Status  string    `json:"status"`
+	Version int32     `json:"version"`
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateOrderStatus, arg.ID, arg.Status, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateRefundStatus = `-- name: UpdateRefundStatus :exec
+UPDATE orders.refunds
+SET status = $2,
+    failure_reason = $3,
+    completed_at = NOW()
+WHERE id = $1
+`
+
+type UpdateRefundStatusParams struct {
+	ID            uuid.UUID      `json:"id"`
+	Status        string         `json:"status"`
+	FailureReason sql.NullString `json:"failure_reason"`
 }
 
-func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error {
-	_, err := q.db.ExecContext(ctx, updateOrderStatus, arg.ID, arg.Status)
+func (q *Queries) UpdateRefundStatus(ctx context.Context, arg UpdateRefundStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateRefundStatus, arg.ID, arg.Status, arg.FailureReason)
 	return err
 }
@@ -12,22 +12,173 @@ INSERT INTO orders.order_items (
     $1, $2, $3, $4, $5, $6, $7
 );
 
--- name: GetOrderWithItems :one
+-- name: GetOrder :one
 SELECT * FROM orders.order WHERE id = $1;
 
+-- name: GetOrderByNumber :one
+SELECT * FROM orders.order WHERE order_number = $1;
+
 -- name: GetOrderItems :many
 SELECT * FROM orders.order_items WHERE order_id = $1;
 
+-- name: GetOrderItemsByOrderIDs :many
+SELECT * FROM orders.order_items WHERE order_id = ANY(sqlc.arg('order_ids')::uuid[]);
+
 -- name: GetAllOrders :many
 SELECT * FROM orders.order;
 
--- name: UpdateOrderStatus :exec
+-- name: UpdateOrderStatus :execrows
 UPDATE orders.order
 SET status = $2,
     updated_at = NOW()
-WHERE id = $1;
+WHERE id = $1
+  AND version = $3;
 
 -- name: GetProductIDsByOrderID :many
 SELECT product_id
 FROM orders.order_items
-WHERE order_id = $1;
\ No newline at end of file
+WHERE order_id = $1;
+
+-- name: GetOrderForUpdate :one
+SELECT * FROM orders.order WHERE id = $1 FOR UPDATE;
+
+
+-- name: MarkOrderPaid :execrows
+UPDATE orders.order
+SET status = $2,
+    payment_id = $3,
+    paid_at = $4
+WHERE id = $1
+  AND version = $5;
+
+
+-- name: InsertOutboxEvent :exec
+INSERT INTO orders.outbox (
+    aggregate_id, event_type, topic, payload
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: ClaimOutboxEvents :many
+UPDATE orders.outbox
+SET locked_until = NOW() + make_interval(secs => sqlc.arg('lease_seconds')::float8)
+WHERE id IN (
+    SELECT o.id FROM orders.outbox o
+    WHERE o.sent_at IS NULL
+      AND (o.locked_until IS NULL OR o.locked_until < NOW())
+      AND o.topic = ANY(sqlc.arg('topics')::text[])
+    ORDER BY o.id
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: ReleaseOutboxEvents :exec
+UPDATE orders.outbox
+SET locked_until = NULL
+WHERE id = ANY(sqlc.arg('ids')::bigint[]);
+
+-- name: MarkOutboxEventSent :exec
+UPDATE orders.outbox
+SET sent_at = NOW()
+WHERE id = $1;
+
+
+-- name: InsertProcessedMessage :execrows
+INSERT INTO orders.processed_messages (
+    topic, kafka_partition, kafka_offset
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT DO NOTHING;
+
+
+-- name: ListOrders :many
+SELECT * FROM orders.order
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id')::uuid)
+  AND (cardinality(sqlc.arg('statuses')::text[]) = 0 OR status = ANY(sqlc.arg('statuses')::text[]))
+  AND (sqlc.narg('ordered_from')::timestamp IS NULL OR ordered_at >= sqlc.narg('ordered_from')::timestamp)
+  AND (sqlc.narg('ordered_to')::timestamp IS NULL OR ordered_at < sqlc.narg('ordered_to')::timestamp)
+  AND (sqlc.narg('payment_method')::varchar IS NULL OR payment_method = sqlc.narg('payment_method')::varchar)
+  AND (sqlc.narg('cursor_ordered_at')::timestamp IS NULL
+       OR (ordered_at, id) < (sqlc.narg('cursor_ordered_at')::timestamp, sqlc.narg('cursor_id')::uuid))
+ORDER BY ordered_at DESC, id DESC
+LIMIT sqlc.arg('page_size');
+
+
+-- name: CancelOrder :execrows
+UPDATE orders.order
+SET status = $2,
+    cancelled_at = NOW(),
+    cancelled_by = $3,
+    cancel_reason = $4
+WHERE id = $1
+  AND version = $5;
+
+
+-- name: InsertRefund :exec
+INSERT INTO orders.refunds (
+    id, order_id, status, amount, full_refund, previous_order_status, reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: InsertRefundItem :exec
+INSERT INTO orders.refund_items (
+    refund_id, order_item_id, quantity, amount
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: GetRefundForUpdate :one
+SELECT * FROM orders.refunds WHERE id = $1 FOR UPDATE;
+
+-- name: UpdateRefundStatus :exec
+UPDATE orders.refunds
+SET status = $2,
+    failure_reason = $3,
+    completed_at = NOW()
+WHERE id = $1;
+
+-- name: GetRefundAmounts :one
+SELECT COALESCE(SUM(amount) FILTER (WHERE status = 'completed'), 0)::bigint AS completed,
+       COALESCE(SUM(amount) FILTER (WHERE status = 'requested'), 0)::bigint AS pending
+FROM orders.refunds
+WHERE order_id = $1;
+
+-- name: GetRefundedItemQuantities :many
+SELECT ri.order_item_id, SUM(ri.quantity)::int AS quantity
+FROM orders.refund_items ri
+JOIN orders.refunds r ON r.id = ri.refund_id
+WHERE r.order_id = $1
+  AND r.status <> 'failed'
+GROUP BY ri.order_item_id;
+
+
+-- name: InsertIdempotencyKey :execrows
+INSERT INTO orders.idempotency_keys (
+    user_id, idempotency_key, fingerprint, order_id
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT DO NOTHING;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM orders.idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2;
+
+
+-- name: NextOrderNumberSeq :one
+SELECT nextval('orders.order_number_seq')::bigint;
+
+-- name: InsertOrderStatusHistory :exec
+INSERT INTO orders.order_status_history (
+    order_id, from_status, to_status, actor, reason, source, source_ref
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: GetOrderStatusHistory :many
+SELECT * FROM orders.order_status_history
+WHERE order_id = $1
+ORDER BY id;
\ No newline at end of file
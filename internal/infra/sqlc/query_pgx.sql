@@ -0,0 +1,13 @@
+-- pgx 전용 쿼리 (COPY, batch). database/sql 패키지에는 생성되지 않는다.
+
+-- name: CopyOrderItems :copyfrom
+INSERT INTO orders.order_items (
+    id, order_id, product_id, product_name, product_price, product_options, quantity
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: MarkOutboxEventsSent :batchexec
+UPDATE orders.outbox
+SET sent_at = NOW()
+WHERE id = $1;
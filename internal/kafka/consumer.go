@@ -2,12 +2,116 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+
+	"github.com/escape-ship/ordersrv/internal/service"
+	kafkaPkg "github.com/escape-ship/ordersrv/pkg/kafka"
+	"github.com/google/uuid"
 )
 
-func PaymentSucceededHandler(ctx context.Context, key, value []byte) error {
+// PaymentHandler 는 결제 관련 토픽의 메시지를 받아 주문에 반영한다.
+type PaymentHandler struct {
+	orders *service.OrderController
+}
+
+func NewPaymentHandler(orders *service.OrderController) *PaymentHandler {
+	return &PaymentHandler{orders: orders}
+}
+
+func (h *PaymentHandler) PaymentSucceeded(ctx context.Context, key, value []byte) error {
 	log.Printf("Processing payment succeeded message: key=%s, value=%s", string(key), string(value))
-	// Handle the payment succeeded message
-	//TODO: Database update
-	return nil
+
+	ev, err := decodePaymentSucceeded(value)
+	if err != nil {
+		return kafkaPkg.Permanent(err)
+	}
+	orderID, err := uuid.Parse(ev.OrderID)
+	if err != nil {
+		return kafkaPkg.Permanent(fmt.Errorf("invalid order ID %q: %w", ev.OrderID, err))
+	}
+
+	msg, err := messageRef(ctx)
+	if err != nil {
+		return kafkaPkg.Permanent(err)
+	}
+
+	err = h.orders.MarkOrderPaid(ctx, msg, service.PaymentResult{
+		OrderID:   orderID,
+		PaymentID: ev.PaymentID,
+		Amount:    ev.Amount,
+		PaidAt:    ev.PaidAt,
+	})
+	return classify(err)
+}
+
+func (h *PaymentHandler) PaymentRefunded(ctx context.Context, key, value []byte) error {
+	log.Printf("Processing payment refunded message: key=%s, value=%s", string(key), string(value))
+
+	ev, err := decodePaymentRefunded(value)
+	if err != nil {
+		return kafkaPkg.Permanent(err)
+	}
+	return h.completeRefund(ctx, ev.RefundID, service.RefundResult{
+		Amount:    ev.Amount,
+		Succeeded: true,
+	})
+}
+
+func (h *PaymentHandler) PaymentRefundFailed(ctx context.Context, key, value []byte) error {
+	log.Printf("Processing payment refund failed message: key=%s, value=%s", string(key), string(value))
+
+	ev, err := decodePaymentRefundFailed(value)
+	if err != nil {
+		return kafkaPkg.Permanent(err)
+	}
+	return h.completeRefund(ctx, ev.RefundID, service.RefundResult{
+		Succeeded:     false,
+		FailureReason: ev.Reason,
+	})
+}
+
+func (h *PaymentHandler) completeRefund(ctx context.Context, refundID string, result service.RefundResult) error {
+	id, err := uuid.Parse(refundID)
+	if err != nil {
+		return kafkaPkg.Permanent(fmt.Errorf("invalid refund ID %q: %w", refundID, err))
+	}
+	result.RefundID = id
+
+	msg, err := messageRef(ctx)
+	if err != nil {
+		return kafkaPkg.Permanent(err)
+	}
+	return classify(h.orders.CompleteRefund(ctx, msg, result))
+}
+
+// classify 는 재시도해도 결과가 같은 도메인 에러를 permanent 로 표시한다. 그 외(DB 장애 등)는 재시도 대상이다.
+func classify(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, service.ErrOrderNotFound),
+		errors.Is(err, service.ErrPaymentAmountMismatch),
+		errors.Is(err, service.ErrRefundNotFound),
+		errors.Is(err, service.ErrRefundNotPending),
+		errors.Is(err, service.ErrRefundAmountMismatch),
+		errors.Is(err, service.ErrInvalidTransition):
+		return kafkaPkg.Permanent(err)
+	default:
+		return err
+	}
+}
+
+// messageRef 는 consumer 가 ctx 에 실어준 메시지 위치를 service.MessageRef 로 바꾼다.
+func messageRef(ctx context.Context) (service.MessageRef, error) {
+	info, ok := kafkaPkg.MessageInfoFromContext(ctx)
+	if !ok {
+		return service.MessageRef{}, errors.New("missing kafka message info in context")
+	}
+	return service.MessageRef{
+		Topic:     info.Topic,
+		Partition: int32(info.Partition),
+		Offset:    info.Offset,
+	}, nil
 }
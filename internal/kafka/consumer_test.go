@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/escape-ship/ordersrv/internal/service"
+	kafkaPkg "github.com/escape-ship/ordersrv/pkg/kafka"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		permanent bool
+	}{
+		{"order not found", service.ErrOrderNotFound, true},
+		{"amount mismatch", fmt.Errorf("%w: paid 1, order total 2", service.ErrPaymentAmountMismatch), true},
+		{"refund not found", service.ErrRefundNotFound, true},
+		{"refund not pending", service.ErrRefundNotPending, true},
+		{"refund amount mismatch", service.ErrRefundAmountMismatch, true},
+		{"invalid transition", &service.TransitionError{From: service.OrderStateCancelled, To: service.OrderStatePaid}, true},
+		{"version conflict", service.ErrVersionConflict, false},
+		{"db error", errors.New("connection refused"), false},
+	}
+	for _, tt := range tests {
+		err := classify(tt.err)
+		if got := kafkaPkg.IsPermanent(err); got != tt.permanent {
+			t.Errorf("%s: IsPermanent(classify()) = %v, want %v", tt.name, got, tt.permanent)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: classify() lost the original error", tt.name)
+		}
+	}
+	if classify(nil) != nil {
+		t.Error("classify(nil) != nil")
+	}
+}
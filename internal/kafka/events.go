@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	paymentSucceededVersion    = 1
+	paymentRefundedVersion     = 1
+	paymentRefundFailedVersion = 1
+)
+
+// PaymentSucceededEvent 는 payment-succeeded 토픽의 메시지 본문이다.
+type PaymentSucceededEvent struct {
+	Version   int       `json:"version"`
+	OrderID   string    `json:"order_id"`
+	PaymentID string    `json:"payment_id"`
+	Amount    int64     `json:"amount"`
+	PaidAt    time.Time `json:"paid_at"`
+}
+
+func decodePaymentSucceeded(value []byte) (*PaymentSucceededEvent, error) {
+	var ev PaymentSucceededEvent
+	if err := json.Unmarshal(value, &ev); err != nil {
+		return nil, fmt.Errorf("decode payment-succeeded: %w", err)
+	}
+	if ev.Version != paymentSucceededVersion {
+		return nil, fmt.Errorf("unsupported payment-succeeded version %d", ev.Version)
+	}
+	// paid_at 이 없으면 0001-01-01 이 결제 시각으로 저장되므로 받지 않는다.
+	if ev.PaidAt.IsZero() {
+		return nil, fmt.Errorf("decode payment-succeeded: paid_at is required")
+	}
+	ev.PaidAt = ev.PaidAt.UTC()
+	return &ev, nil
+}
+
+// PaymentRefundedEvent 는 payment-refunded 토픽의 메시지 본문이다.
+type PaymentRefundedEvent struct {
+	Version    int       `json:"version"`
+	RefundID   string    `json:"refund_id"`
+	OrderID    string    `json:"order_id"`
+	Amount     int64     `json:"amount"`
+	RefundedAt time.Time `json:"refunded_at"`
+}
+
+// PaymentRefundFailedEvent 는 payment-refund-failed 토픽의 메시지 본문이다.
+type PaymentRefundFailedEvent struct {
+	Version  int       `json:"version"`
+	RefundID string    `json:"refund_id"`
+	OrderID  string    `json:"order_id"`
+	Reason   string    `json:"reason"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+func decodePaymentRefunded(value []byte) (*PaymentRefundedEvent, error) {
+	var ev PaymentRefundedEvent
+	if err := json.Unmarshal(value, &ev); err != nil {
+		return nil, fmt.Errorf("decode payment-refunded: %w", err)
+	}
+	if ev.Version != paymentRefundedVersion {
+		return nil, fmt.Errorf("unsupported payment-refunded version %d", ev.Version)
+	}
+	return &ev, nil
+}
+
+func decodePaymentRefundFailed(value []byte) (*PaymentRefundFailedEvent, error) {
+	var ev PaymentRefundFailedEvent
+	if err := json.Unmarshal(value, &ev); err != nil {
+		return nil, fmt.Errorf("decode payment-refund-failed: %w", err)
+	}
+	if ev.Version != paymentRefundFailedVersion {
+		return nil, fmt.Errorf("unsupported payment-refund-failed version %d", ev.Version)
+	}
+	return &ev, nil
+}
@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodePaymentSucceeded(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"valid", `{"version":1,"order_id":"o","payment_id":"p","amount":1000,"paid_at":"2025-01-02T03:04:05Z"}`, false},
+		// 오프셋이 있어도 같은 시각의 UTC 로 바뀐다.
+		{"non-UTC offset", `{"version":1,"order_id":"o","payment_id":"p","amount":1000,"paid_at":"2025-01-02T12:04:05+09:00"}`, false},
+		{"missing paid_at", `{"version":1,"order_id":"o","payment_id":"p","amount":1000}`, true},
+		{"zero paid_at", `{"version":1,"order_id":"o","payment_id":"p","amount":1000,"paid_at":"0001-01-01T00:00:00Z"}`, true},
+		{"unsupported version", `{"version":2,"order_id":"o","payment_id":"p","amount":1000,"paid_at":"2025-01-02T03:04:05Z"}`, true},
+		{"malformed", `{`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := decodePaymentSucceeded([]byte(tt.value))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodePaymentSucceeded() = %+v, want error", ev)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePaymentSucceeded() error = %v", err)
+			}
+			// paid_at 컬럼은 TIMESTAMP 라 pgx 가 벽시계 값만 저장한다. 위치까지 UTC 여야 한다.
+			if want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC); ev.PaidAt != want {
+				t.Fatalf("PaidAt = %v, want %v", ev.PaidAt, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/pgxdb"
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	"github.com/escape-ship/ordersrv/pkg/kafka"
+	"github.com/escape-ship/ordersrv/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	_defaultInterval  = time.Second
+	_defaultBatchSize = 100
+	_defaultLease     = 30 * time.Second
+
+	// NotifyChannel 은 orders.outbox insert 트리거가 pg_notify 하는 채널이다.
+	NotifyChannel = "orders_outbox"
+)
+
+// Notifier 는 postgres 알림을 구독할 수 있는 DB 엔진이다. postgres.PoolEngine 이 구현한다.
+type Notifier interface {
+	Listen(ctx context.Context, channel string, fn func(*pgconn.Notification)) error
+}
+
+type RelayOption func(*Relay)
+
+// WithNotifier 를 주면 outbox insert 알림을 받는 즉시 발행한다. 폴링은 알림을 놓쳤을 때를 위해 그대로 돈다.
+func WithNotifier(n Notifier) RelayOption {
+	return func(r *Relay) {
+		r.notifier = n
+	}
+}
+
+// WithPool 을 주면 발행 결과(sent 표시, lease 해제)를 pgx batch 로 한 번에 반영한다.
+func WithPool(pool *pgxpool.Pool) RelayOption {
+	return func(r *Relay) {
+		r.pool = pool
+	}
+}
+
+// Relay 는 orders.outbox 에 쌓인 이벤트를 토픽별 Publisher 로 발행하고 발행된 row 를 sent 로 표시한다.
+// 발행 후 sent 표시 전에 실패하면 같은 이벤트가 다시 발행될 수 있다(at-least-once).
+// Publisher 가 없는 토픽의 이벤트는 가져가지 않고 pending 으로 남긴다.
+type Relay struct {
+	pg         postgres.DBEngine
+	publishers map[string]kafka.Publisher
+	topics     []string
+	interval   time.Duration
+	batchSize  int32
+	lease      time.Duration
+	notifier   Notifier
+	pool       *pgxpool.Pool
+	wake       chan struct{}
+}
+
+func NewRelay(pg postgres.DBEngine, publishers map[string]kafka.Publisher, opts ...RelayOption) *Relay {
+	r := &Relay{
+		pg:         pg,
+		publishers: publishers,
+		interval:   _defaultInterval,
+		batchSize:  _defaultBatchSize,
+		lease:      _defaultLease,
+		wake:       make(chan struct{}, 1),
+	}
+	for topic := range publishers {
+		r.topics = append(r.topics, topic)
+	}
+	sort.Strings(r.topics)
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run 은 ctx 가 끝날 때까지 interval 마다 outbox 를 비운다.
+func (r *Relay) Run(ctx context.Context) {
+	if r.notifier != nil {
+		go r.listen(ctx)
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.wake:
+		}
+		if err := r.drain(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Outbox: relay error: %v", err)
+		}
+	}
+}
+
+// listen 은 ctx 가 끝날 때까지 outbox 알림을 구독한다. 연결이 끊기면 interval 뒤에 다시 구독한다.
+func (r *Relay) listen(ctx context.Context) {
+	for {
+		err := r.notifier.Listen(ctx, NotifyChannel, func(*pgconn.Notification) {
+			select {
+			case r.wake <- struct{}{}:
+			default: // 이미 깨울 예정이다
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Outbox: listen %q error: %v", NotifyChannel, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// drain 은 발행할 이벤트가 없거나 에러가 날 때까지 배치 단위로 발행한다.
+func (r *Relay) drain(ctx context.Context) error {
+	for {
+		n, err := r.relayBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if n < int(r.batchSize) {
+			return nil
+		}
+	}
+}
+
+// relayBatch 는 batchSize 만큼 이벤트를 lease 로 가져와 발행하고 발행된 것을 sent 로 표시한다.
+// 발행은 트랜잭션 밖에서 한다. DB 작업만 WithTx 로 재시도되므로 재시도로 같은 배치가 다시 발행되지 않는다.
+// 이벤트는 토픽마다 한 번에 발행한다. 가져온 이벤트 수를 반환하며,
+// 발행에 실패하면 남은 이벤트의 lease 를 풀고 에러를 반환한다.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	var events []postgresql.OrdersOutbox
+	err := r.pg.WithTx(ctx, nil, func(tx *sql.Tx) error {
+		var err error
+		events, err = postgresql.New(tx).ClaimOutboxEvents(ctx, postgresql.ClaimOutboxEventsParams{
+			LeaseSeconds: r.lease.Seconds(),
+			Topics:       r.topics,
+			BatchSize:    r.batchSize,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+	// UPDATE ... RETURNING 은 순서를 보장하지 않는다.
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	byTopic := make(map[string][]postgresql.OrdersOutbox)
+	for _, ev := range events {
+		byTopic[ev.Topic] = append(byTopic[ev.Topic], ev)
+	}
+
+	// lease 가 끝나면 다른 인스턴스가 같은 이벤트를 다시 가져가므로 발행은 lease 의 절반 안에 끝낸다.
+	pctx, cancel := context.WithTimeout(ctx, r.lease/2)
+	defer cancel()
+
+	var (
+		sent       []int64
+		unsent     []int64
+		publishErr error
+	)
+	for _, topic := range r.topics {
+		evs := byTopic[topic]
+		if len(evs) == 0 {
+			continue
+		}
+		ids := eventIDs(evs)
+		// 브로커가 죽었으면 다른 토픽도 실패할 것이므로 첫 실패 이후는 발행하지 않는다.
+		if publishErr != nil {
+			unsent = append(unsent, ids...)
+			continue
+		}
+		// 토픽 안에서는 id 순서대로 한 번에 쓰므로 같은 aggregate 의 이벤트 순서가 유지된다.
+		msgs := make([]kafka.Message, len(evs))
+		for i, ev := range evs {
+			msgs[i] = kafka.Message{Key: []byte(ev.AggregateID.String()), Value: ev.Payload}
+		}
+		if err := r.publishers[topic].PublishBatch(pctx, msgs...); err != nil {
+			publishErr = fmt.Errorf("publish %d event(s) to %q: %w", len(evs), topic, err)
+			unsent = append(unsent, ids...)
+			continue
+		}
+		sent = append(sent, ids...)
+	}
+
+	return len(events), errors.Join(publishErr, r.finish(ctx, sent, unsent))
+}
+
+func eventIDs(events []postgresql.OrdersOutbox) []int64 {
+	ids := make([]int64, len(events))
+	for i, ev := range events {
+		ids[i] = ev.ID
+	}
+	return ids
+}
+
+// finish 는 발행된 이벤트를 sent 로 표시하고 발행하지 못한 이벤트의 lease 를 푼다.
+func (r *Relay) finish(ctx context.Context, sent, unsent []int64) error {
+	if r.pool != nil {
+		return r.finishBatch(ctx, sent, unsent)
+	}
+	return r.pg.WithTx(ctx, nil, func(tx *sql.Tx) error {
+		qtx := postgresql.New(tx)
+		for _, id := range sent {
+			if err := qtx.MarkOutboxEventSent(ctx, id); err != nil {
+				return err
+			}
+		}
+		if len(unsent) > 0 {
+			return qtx.ReleaseOutboxEvents(ctx, unsent)
+		}
+		return nil
+	})
+}
+
+// finishBatch 는 sent 표시를 pgx batch 하나로 보내 이벤트마다 왕복하지 않는다.
+// 실패하면 lease 가 끝난 뒤 같은 이벤트가 다시 발행된다.
+func (r *Relay) finishBatch(ctx context.Context, sent, unsent []int64) error {
+	return pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		q := pgxdb.New(tx)
+		if len(sent) > 0 {
+			var errs []error
+			q.MarkOutboxEventsSent(ctx, sent).Exec(func(i int, err error) {
+				if err != nil {
+					errs = append(errs, fmt.Errorf("mark event %d sent: %w", sent[i], err))
+				}
+			})
+			if err := errors.Join(errs...); err != nil {
+				return err
+			}
+		}
+		if len(unsent) > 0 {
+			return q.ReleaseOutboxEvents(ctx, unsent)
+		}
+		return nil
+	})
+}
+
+// Close 는 Relay 가 사용하는 모든 Publisher 를 닫는다.
+func (r *Relay) Close() error {
+	var errs []error
+	for _, pub := range r.publishers {
+		errs = append(errs, pub.Close())
+	}
+	return errors.Join(errs...)
+}
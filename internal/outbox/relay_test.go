@@ -0,0 +1,294 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/escape-ship/ordersrv/pkg/kafka"
+	"github.com/escape-ship/ordersrv/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+// outboxRow 는 fakeOutbox 에 들어있는 orders.outbox row 다.
+type outboxRow struct {
+	id     int64
+	topic  string
+	sent   bool
+	locked bool
+}
+
+// fakeOutbox 는 relay 가 쓰는 outbox 쿼리(claim, mark sent, release)만 흉내내는 database/sql 드라이버다.
+type fakeOutbox struct {
+	mu    sync.Mutex
+	rows  []*outboxRow
+	lease float64 // 마지막 claim 의 lease 초
+}
+
+func (f *fakeOutbox) Connect(context.Context) (driver.Conn, error) { return &fakeOutboxConn{f: f}, nil }
+func (f *fakeOutbox) Driver() driver.Driver                        { return nil }
+
+func (f *fakeOutbox) row(id int64) *outboxRow {
+	for _, r := range f.rows {
+		if r.id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// state 는 sent, locked 인 row id 를 돌려준다.
+func (f *fakeOutbox) state() (sent, locked []int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.rows {
+		if r.sent {
+			sent = append(sent, r.id)
+		}
+		if r.locked {
+			locked = append(locked, r.id)
+		}
+	}
+	return sent, locked
+}
+
+type fakeOutboxConn struct{ f *fakeOutbox }
+
+func (c *fakeOutboxConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fakeOutboxConn: prepare not supported")
+}
+func (c *fakeOutboxConn) Close() error              { return nil }
+func (c *fakeOutboxConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeOutboxConn) Commit() error             { return nil }
+func (c *fakeOutboxConn) Rollback() error           { return nil }
+
+func (c *fakeOutboxConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if !strings.Contains(query, "-- name: ClaimOutboxEvents ") {
+		return nil, fmt.Errorf("fakeOutboxConn: unexpected query %q", query)
+	}
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	c.f.lease = args[0].Value.(float64)
+	topics := parseArray(args[1].Value)
+	limit := args[2].Value.(int64)
+
+	rows := &fakeRows{}
+	now := time.Now()
+	for _, r := range c.f.rows {
+		if r.sent || r.locked || !slices.Contains(topics, r.topic) || int64(len(rows.data)) == limit {
+			continue
+		}
+		r.locked = true
+		rows.data = append(rows.data, []driver.Value{
+			r.id, aggregateID(r.id).String(), "order.created", r.topic, []byte("{}"), now, nil, now,
+		})
+	}
+	// UPDATE ... RETURNING 처럼 순서를 섞어서 돌려준다.
+	slices.Reverse(rows.data)
+	return rows, nil
+}
+
+func (c *fakeOutboxConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	switch {
+	case strings.Contains(query, "-- name: MarkOutboxEventSent "):
+		if r := c.f.row(args[0].Value.(int64)); r != nil {
+			r.sent, r.locked = true, false
+		}
+	case strings.Contains(query, "-- name: ReleaseOutboxEvents "):
+		for _, s := range parseArray(args[0].Value) {
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if r := c.f.row(id); r != nil {
+				r.locked = false
+			}
+		}
+	default:
+		return nil, fmt.Errorf("fakeOutboxConn: unexpected exec %q", query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+// parseArray 는 pq.Array 가 만든 "{a,b}" 형식을 나눈다.
+func parseArray(v driver.Value) []string {
+	s := strings.Trim(fmt.Sprint(v), "{}")
+	if s == "" {
+		return nil
+	}
+	var res []string
+	for _, e := range strings.Split(s, ",") {
+		res = append(res, strings.Trim(e, `"`))
+	}
+	return res
+}
+
+func aggregateID(id int64) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(strconv.FormatInt(id, 10)))
+}
+
+type fakeRows struct {
+	data [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"id", "aggregate_id", "event_type", "topic", "payload", "created_at", "sent_at", "locked_until"}
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.pos])
+	r.pos++
+	return nil
+}
+
+// fakeEngine 은 fakeOutbox 로 연 *sql.DB 를 쓰는 DBEngine 이다.
+type fakeEngine struct{ db *sql.DB }
+
+func (e *fakeEngine) Configure(...postgres.Option) postgres.DBEngine { return e }
+func (e *fakeEngine) GetDB() *sql.DB                                 { return e.db }
+func (e *fakeEngine) GetReadDB() *sql.DB                             { return e.db }
+func (e *fakeEngine) Ping(ctx context.Context) error                 { return e.db.PingContext(ctx) }
+func (e *fakeEngine) Close()                                         { e.db.Close() }
+func (e *fakeEngine) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*sql.Tx) error) error {
+	tx, err := e.db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// fakePublisher 는 PublishBatch 호출을 기록한다. err 가 있으면 실패한다.
+type fakePublisher struct {
+	err      error
+	batches  [][]string // 호출마다 메시지 key
+	deadline time.Duration
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, key, value []byte, headers ...kafka.Header) error {
+	return p.PublishBatch(ctx, kafka.Message{Key: key, Value: value, Headers: headers})
+}
+
+func (p *fakePublisher) PublishBatch(ctx context.Context, msgs ...kafka.Message) error {
+	if d, ok := ctx.Deadline(); ok {
+		p.deadline = time.Until(d)
+	}
+	var keys []string
+	for _, m := range msgs {
+		keys = append(keys, string(m.Key))
+	}
+	p.batches = append(p.batches, keys)
+	return p.err
+}
+
+func (p *fakePublisher) Close() error { return nil }
+
+func keys(ids ...int64) []string {
+	var res []string
+	for _, id := range ids {
+		res = append(res, aggregateID(id).String())
+	}
+	return res
+}
+
+func TestRelayBatch(t *testing.T) {
+	errBroker := errors.New("broker unavailable")
+	tests := []struct {
+		name     string
+		failA    error
+		failB    error
+		batchesA [][]string
+		batchesB [][]string
+		sent     []int64
+		wantErr  bool
+	}{
+		{
+			name:     "all published",
+			batchesA: [][]string{keys(1, 3, 4)},
+			batchesB: [][]string{keys(2)},
+			sent:     []int64{1, 2, 3, 4},
+		},
+		// 첫 토픽이 실패하면 다음 토픽은 발행하지 않고 모두 lease 를 푼다.
+		{
+			name:     "first topic fails",
+			failA:    errBroker,
+			batchesA: [][]string{keys(1, 3, 4)},
+			wantErr:  true,
+		},
+		{
+			name:     "second topic fails",
+			failB:    errBroker,
+			batchesA: [][]string{keys(1, 3, 4)},
+			batchesB: [][]string{keys(2)},
+			sent:     []int64{1, 3, 4},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeOutbox{rows: []*outboxRow{
+				{id: 1, topic: "a"}, {id: 2, topic: "b"}, {id: 3, topic: "a"}, {id: 4, topic: "a"},
+				{id: 5, topic: "unknown"}, // Publisher 가 없는 토픽은 가져가지 않는다.
+			}}
+			pa, pb := &fakePublisher{err: tt.failA}, &fakePublisher{err: tt.failB}
+			r := NewRelay(&fakeEngine{db: sql.OpenDB(f)}, map[string]kafka.Publisher{"b": pb, "a": pa})
+
+			n, err := r.relayBatch(context.Background())
+			if n != 4 {
+				t.Errorf("relayBatch() = %d events, want 4", n)
+			}
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, errBroker)) {
+				t.Fatalf("relayBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.EqualFunc(pa.batches, tt.batchesA, slices.Equal) {
+				t.Errorf("topic a batches = %v, want %v", pa.batches, tt.batchesA)
+			}
+			if !slices.EqualFunc(pb.batches, tt.batchesB, slices.Equal) {
+				t.Errorf("topic b batches = %v, want %v", pb.batches, tt.batchesB)
+			}
+			sent, locked := f.state()
+			if !slices.Equal(sent, tt.sent) {
+				t.Errorf("sent rows = %v, want %v", sent, tt.sent)
+			}
+			// 발행하지 못한 이벤트는 lease 가 끝나기를 기다리지 않고 바로 다시 가져갈 수 있어야 한다.
+			if len(locked) != 0 {
+				t.Errorf("locked rows = %v, want none", locked)
+			}
+		})
+	}
+}
+
+func TestRelayBatchLease(t *testing.T) {
+	f := &fakeOutbox{rows: []*outboxRow{{id: 1, topic: "a"}}}
+	p := &fakePublisher{}
+	r := NewRelay(&fakeEngine{db: sql.OpenDB(f)}, map[string]kafka.Publisher{"a": p})
+
+	if _, err := r.relayBatch(context.Background()); err != nil {
+		t.Fatalf("relayBatch() error = %v", err)
+	}
+	if f.lease != _defaultLease.Seconds() {
+		t.Errorf("claimed with lease %vs, want %v", f.lease, _defaultLease)
+	}
+	// 다른 인스턴스가 다시 가져가기 전에 발행이 끝나야 한다.
+	if p.deadline <= 0 || p.deadline > _defaultLease/2 {
+		t.Errorf("publish deadline = %v, want within %v", p.deadline, _defaultLease/2)
+	}
+}
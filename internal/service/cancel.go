@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	pb "github.com/escape-ship/protos/gen"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 취소 가능한 상태
+var cancellableStates = map[OrderStatus]bool{
+	OrderStateReceived:  true,
+	OrderStatePaid:      true,
+	OrderStatePreparing: true,
+}
+
+// CancelOrder 는 received/paid/preparing 상태의 주문을 취소한다.
+// 이미 결제된 주문은 refunding 으로 바꾸고 결제 서비스에 refund-requested 이벤트를 발행한다.
+// 환불이 끝나면 cancelled, 실패하면 refund_failed 가 된다(CompleteRefund 참고).
+// expected_version 이 0 이 아니면 주문 버전이 같을 때만 취소한다. 다르면 Aborted 를 반환한다.
+// 응답의 status 는 취소 후 주문 상태(cancelled 또는 refunding)다.
+func (s *OrderController) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "cancel reason is required")
+	}
+
+	var (
+		next    OrderStatus
+		version int32
+	)
+	err = s.inTx(ctx, func(qtx *postgresql.Queries) error {
+		order, err := qtx.GetOrderForUpdate(ctx, orderID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(order, req.ExpectedVersion); err != nil {
+			return err
+		}
+		from := OrderStatus(order.Status)
+		if !cancellableStates[from] {
+			return &TransitionError{From: from, To: OrderStateCancelled}
+		}
+		next = OrderStateCancelled
+		if from != OrderStateReceived {
+			next = OrderStateRefunding
+		}
+		if err := checkTransition(from, next); err != nil {
+			return err
+		}
+
+		err = versionedUpdate(qtx.CancelOrder(ctx, postgresql.CancelOrderParams{
+			ID:           orderID,
+			Status:       string(next),
+			CancelledBy:  parseNullString(req.CancelledBy),
+			CancelReason: parseNullString(req.Reason),
+			Version:      order.Version,
+		}))
+		if err != nil {
+			return err
+		}
+		version = order.Version + 1
+		if err := recordStatusChange(ctx, qtx, orderID, from, next, GRPCChange(req.CancelledBy, req.Reason)); err != nil {
+			return err
+		}
+
+		if next != OrderStateRefunding {
+			return nil
+		}
+		// 보상 트랜잭션: 결제 서비스에 전체 환불 요청
+		_, err = requestRefund(ctx, qtx, order, req.Reason, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CancelOrderResponse{Status: string(next), Version: version}, nil
+}
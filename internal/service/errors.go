@@ -0,0 +1,45 @@
+package service
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrOrderNotFound 는 조회/변경 대상 주문이 없을 때 반환된다.
+	ErrOrderNotFound = status.Error(codes.NotFound, "order not found")
+	// ErrPaymentAmountMismatch 는 결제 금액이 주문 총액과 다를 때 반환된다.
+	ErrPaymentAmountMismatch = status.Error(codes.FailedPrecondition, "payment amount does not match order total")
+	// ErrRefundNotFound 는 환불 결과 이벤트가 가리키는 환불 요청이 없을 때 반환된다.
+	ErrRefundNotFound = status.Error(codes.NotFound, "refund not found")
+	// ErrRefundNotPending 은 이미 처리가 끝난 환불에 결과를 반영하려 할 때 반환된다.
+	ErrRefundNotPending = status.Error(codes.FailedPrecondition, "refund is not pending")
+	// ErrRefundAmountMismatch 는 환불된 금액이 요청 금액과 다를 때 반환된다.
+	ErrRefundAmountMismatch = status.Error(codes.FailedPrecondition, "refunded amount does not match requested amount")
+	// ErrVersionConflict 는 주문이 호출자가 읽은 뒤 다른 요청에 의해 바뀌었을 때 반환된다. 다시 읽고 재시도하면 된다.
+	ErrVersionConflict = status.Error(codes.Aborted, "order was modified concurrently")
+	// ErrIdempotencyKeyReused 는 같은 idempotency key 로 내용이 다른 요청이 왔을 때 반환된다.
+	ErrIdempotencyKeyReused = status.Error(codes.AlreadyExists, "idempotency key was already used with a different request")
+)
+
+type fieldViolation struct {
+	Field       string
+	Description string
+}
+
+// invalidArgument 는 필드 단위 위반 내역을 errdetails.BadRequest 로 담은 InvalidArgument 에러를 만든다.
+func invalidArgument(msg string, violations []fieldViolation) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(br)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	"github.com/google/uuid"
+)
+
+// 주문 도메인 이벤트가 발행되는 토픽
+const (
+	TopicOrderCreated       = "order-created"
+	TopicOrderStatusChanged = "order-status-changed"
+	TopicRefundRequested    = "refund-requested"
+)
+
+const orderEventVersion = 1
+
+type OrderCreatedEvent struct {
+	Version     int       `json:"version"`
+	OrderID     string    `json:"order_id"`
+	UserID      string    `json:"user_id"`
+	OrderNumber string    `json:"order_number"`
+	Status      string    `json:"status"`
+	TotalPrice  int64     `json:"total_price"`
+	Quantity    int32     `json:"quantity"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+type OrderStatusChangedEvent struct {
+	Version    int       `json:"version"`
+	OrderID    string    `json:"order_id"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// RefundRequestedEvent 는 결제 서비스에 환불을 요청하는 이벤트다.
+// Items 가 비어있으면 전체 환불이다.
+type RefundRequestedEvent struct {
+	Version    int               `json:"version"`
+	RefundID   string            `json:"refund_id"`
+	OrderID    string            `json:"order_id"`
+	PaymentID  string            `json:"payment_id"`
+	Amount     int64             `json:"amount"`
+	Reason     string            `json:"reason"`
+	Items      []RefundItemEvent `json:"items,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+type RefundItemEvent struct {
+	OrderItemID string `json:"order_item_id"`
+	ProductID   string `json:"product_id"`
+	Quantity    int32  `json:"quantity"`
+	Amount      int64  `json:"amount"`
+}
+
+// enqueueEvent 는 이벤트를 outbox 테이블에 기록한다. 호출자의 트랜잭션(qtx) 안에서 실행되어야 하며,
+// 실제 발행은 outbox.Relay 가 담당한다.
+func enqueueEvent(ctx context.Context, qtx *postgresql.Queries, topic string, aggregateID uuid.UUID, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal %s event: %w", topic, err)
+	}
+	return qtx.InsertOutboxEvent(ctx, postgresql.InsertOutboxEventParams{
+		AggregateID: aggregateID,
+		EventType:   topic,
+		Topic:       topic,
+		Payload:     payload,
+	})
+}
+
+// recordStatusChange 는 상태 전이에 따른 부수 기록(상태 이력, 도메인 이벤트)을 남긴다.
+func recordStatusChange(ctx context.Context, qtx *postgresql.Queries, orderID uuid.UUID, from, to OrderStatus, change StatusChange) error {
+	if err := insertStatusHistory(ctx, qtx, orderID, from, to, change); err != nil {
+		return err
+	}
+	return enqueueEvent(ctx, qtx, TopicOrderStatusChanged, orderID, OrderStatusChangedEvent{
+		Version:    orderEventVersion,
+		OrderID:    orderID.String(),
+		From:       string(from),
+		To:         string(to),
+		OccurredAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	pb "github.com/escape-ship/protos/gen"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetOrder 는 id 또는 order_number 중 하나로 주문 한 건과 아이템을 조회한다.
+// 주문이 없으면 NotFound 를 반환한다. 응답의 version 을 변경 요청의 expected_version 으로 넘기면
+// 동시 수정을 감지할 수 있다.
+func (s *OrderController) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	querier := s.readQueries(ctx)
+
+	var (
+		order postgresql.OrdersOrder
+		err   error
+	)
+	switch {
+	case req.Id != "" && req.OrderNumber != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of ID and order number may be set")
+	case req.Id != "":
+		id, perr := uuid.Parse(req.Id)
+		if perr != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", perr)
+		}
+		order, err = querier.GetOrder(ctx, id)
+	case req.OrderNumber != "":
+		order, err = querier.GetOrderByNumber(ctx, req.OrderNumber)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "order ID or order number is required")
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := querier.GetOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetOrderResponse{
+		Order:   toPBOrder(order, items),
+		Version: order.Version,
+	}, nil
+}
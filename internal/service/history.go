@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	pb "github.com/escape-ship/protos/gen"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// 상태 변경이 들어온 경로
+const (
+	ChangeSourceGRPC  = "grpc"
+	ChangeSourceKafka = "kafka"
+)
+
+// SystemActor 는 사용자가 아닌 서비스 간 이벤트로 일어난 변경의 actor 다.
+const SystemActor = "system"
+
+// StatusChange 는 상태 전이를 누가, 왜, 어떤 경로로 일으켰는지를 담는다.
+type StatusChange struct {
+	Actor     string
+	Reason    string
+	Source    string
+	SourceRef string // Kafka 메시지면 "topic/partition@offset"
+}
+
+// GRPCChange 는 gRPC 요청으로 일어난 상태 변경이다.
+func GRPCChange(actor, reason string) StatusChange {
+	return StatusChange{Actor: actor, Reason: reason, Source: ChangeSourceGRPC}
+}
+
+// kafkaChange 는 Kafka 메시지로 일어난 상태 변경이다.
+func kafkaChange(msg MessageRef, reason string) StatusChange {
+	return StatusChange{
+		Actor:     SystemActor,
+		Reason:    reason,
+		Source:    ChangeSourceKafka,
+		SourceRef: fmt.Sprintf("%s/%d@%d", msg.Topic, msg.Partition, msg.Offset),
+	}
+}
+
+// GetOrderHistory 는 주문의 상태 변경 이력을 오래된 순서로 반환한다.
+// 주문 생성 이력은 from_status 가 비어있고, source_ref 는 Kafka 메시지면 "topic/partition@offset" 이다.
+func (s *OrderController) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+
+	querier := s.readQueries(ctx)
+	if _, err := querier.GetOrder(ctx, orderID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	rows, err := querier.GetOrderStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetOrderHistoryResponse{Entries: make([]*pb.OrderStatusHistory, 0, len(rows))}
+	for _, r := range rows {
+		resp.Entries = append(resp.Entries, &pb.OrderStatusHistory{
+			FromStatus: r.FromStatus.String,
+			ToStatus:   r.ToStatus,
+			Actor:      r.Actor,
+			Reason:     r.Reason.String,
+			Source:     r.Source,
+			SourceRef:  r.SourceRef.String,
+			ChangedAt:  r.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+// insertStatusHistory 는 상태 이력 한 줄을 남긴다. from 이 빈 값이면 주문 생성이다.
+func insertStatusHistory(ctx context.Context, qtx *postgresql.Queries, orderID uuid.UUID, from, to OrderStatus, change StatusChange) error {
+	return qtx.InsertOrderStatusHistory(ctx, postgresql.InsertOrderStatusHistoryParams{
+		OrderID:    orderID,
+		FromStatus: parseNullString(string(from)),
+		ToStatus:   string(to),
+		Actor:      change.Actor,
+		Reason:     parseNullString(change.Reason),
+		Source:     change.Source,
+		SourceRef:  parseNullString(change.SourceRef),
+	})
+}
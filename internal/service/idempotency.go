@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKeyHeader 는 클라이언트가 재시도 시 같은 값을 보내야 하는 gRPC metadata 키다.
+const IdempotencyKeyHeader = "idempotency-key"
+
+const _maxIdempotencyKeyLen = 255
+
+// idempotencyKey 는 요청 metadata 의 idempotency key 를 반환한다. 없으면 빈 문자열이다.
+func idempotencyKey(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	values := md.Get(IdempotencyKeyHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+	key := values[0]
+	if len(key) > _maxIdempotencyKeyLen {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d bytes", IdempotencyKeyHeader, _maxIdempotencyKeyLen)
+	}
+	return key, nil
+}
+
+// requestFingerprint 는 요청 본문의 SHA-256 해시다. 같은 key 로 다른 요청이 왔는지 구분하는 데 쓴다.
+func requestFingerprint(req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claimIdempotencyKey 는 (userID, key) 를 orderID 에 묶는다. 이미 같은 요청으로 사용된 key 면
+// 기존 주문 ID 와 true 를, 다른 요청으로 사용된 key 면 ErrIdempotencyKeyReused 를 반환한다.
+// 동시에 같은 key 로 들어온 요청은 먼저 들어온 트랜잭션이 끝날 때까지 기다린다.
+func claimIdempotencyKey(ctx context.Context, qtx *postgresql.Queries, userID uuid.UUID, key, fingerprint string, orderID uuid.UUID) (uuid.UUID, bool, error) {
+	n, err := qtx.InsertIdempotencyKey(ctx, postgresql.InsertIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		Fingerprint:    fingerprint,
+		OrderID:        orderID,
+	})
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	if n == 1 {
+		return uuid.Nil, false, nil
+	}
+
+	existing, err := qtx.GetIdempotencyKey(ctx, postgresql.GetIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+	if existing.Fingerprint != fingerprint {
+		return uuid.Nil, false, ErrIdempotencyKeyReused
+	}
+	return existing.OrderID, true, nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+)
+
+// MessageRef 는 처리 중인 Kafka 메시지의 위치(topic/partition/offset)다.
+type MessageRef struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+// inMessageTx 는 inTx 와 같지만, 같은 트랜잭션에서 orders.processed_messages 에 msg 를 기록한다.
+// 이미 처리된 메시지면 fn 을 실행하지 않고 nil 을 반환하므로 재전달된 메시지는 한 번만 반영된다.
+func (s *OrderController) inMessageTx(ctx context.Context, msg MessageRef, fn func(qtx *postgresql.Queries) error) error {
+	return s.inTx(ctx, func(qtx *postgresql.Queries) error {
+		n, err := qtx.InsertProcessedMessage(ctx, postgresql.InsertProcessedMessageParams{
+			Topic:          msg.Topic,
+			KafkaPartition: msg.Partition,
+			KafkaOffset:    msg.Offset,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			log.Printf("skip already processed message: topic=%s partition=%d offset=%d", msg.Topic, msg.Partition, msg.Offset)
+			return nil
+		}
+		return fn(qtx)
+	})
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	pb "github.com/escape-ship/protos/gen"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_defaultPageSize = 20
+	_maxPageSize     = 100
+)
+
+// ListOrders 는 (ordered_at, id) 내림차순 keyset 페이지네이션으로 주문을 조회한다.
+// 비어있는 필터는 적용하지 않는다. ordered_from(포함)/ordered_to(미포함)는 RFC3339 시각이다.
+// NextPageToken 이 비어있으면 마지막 페이지다.
+func (s *OrderController) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	orderedFrom, err := parseTimeFilter("ordered_from", req.OrderedFrom)
+	if err != nil {
+		return nil, err
+	}
+	orderedTo, err := parseTimeFilter("ordered_to", req.OrderedTo)
+	if err != nil {
+		return nil, err
+	}
+	params := postgresql.ListOrdersParams{
+		OrderedFrom:   parseNullTimeValue(orderedFrom),
+		OrderedTo:     parseNullTimeValue(orderedTo),
+		PaymentMethod: parseNullString(req.PaymentMethod),
+		Statuses:      []string{},
+	}
+
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid user ID: %v", err)
+		}
+		params.UserID = uuid.NullUUID{UUID: userID, Valid: true}
+	}
+	for _, raw := range req.Statuses {
+		st := OrderStatus(raw)
+		if !st.Valid() {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown order status %q", st)
+		}
+		params.Statuses = append(params.Statuses, string(st))
+	}
+
+	pageSize := req.PageSize
+	switch {
+	case pageSize <= 0:
+		pageSize = _defaultPageSize
+	case pageSize > _maxPageSize:
+		pageSize = _maxPageSize
+	}
+	// 다음 페이지 존재 여부를 알기 위해 하나 더 조회한다.
+	params.PageSize = pageSize + 1
+
+	if req.PageToken != "" {
+		orderedAt, id, err := decodePageToken(req.PageToken)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		params.CursorOrderedAt = parseNullTimeValue(orderedAt)
+		params.CursorID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	querier := s.readQueries(ctx)
+	orders, err := querier.ListOrders(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListOrdersResponse{}
+	if len(orders) > int(pageSize) {
+		orders = orders[:pageSize]
+		last := orders[len(orders)-1]
+		resp.NextPageToken = encodePageToken(last.OrderedAt, last.ID)
+	}
+	itemsByOrder, err := loadOrderItems(ctx, querier, orders)
+	if err != nil {
+		return nil, err
+	}
+	resp.Orders = toPBOrders(orders, itemsByOrder)
+	return resp, nil
+}
+
+// ListOrdersByUser 는 user_id 로 필터링한 ListOrders 다.
+func (s *OrderController) ListOrdersByUser(ctx context.Context, req *pb.ListOrdersByUserRequest) (*pb.ListOrdersResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user ID is required")
+	}
+	return s.ListOrders(ctx, &pb.ListOrdersRequest{
+		UserId:    req.UserId,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+}
+
+// parseTimeFilter 는 RFC3339 시각 필터를 UTC 로 파싱한다. 빈 값이면 zero time 이다.
+// ordered_at 은 TIMESTAMP 라 pgx 가 오프셋을 버리므로 UTC 로 맞추지 않으면 비교가 어긋난다.
+func parseTimeFilter(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, invalidArgument("invalid list filter", []fieldViolation{
+			{Field: field, Description: fmt.Sprintf("must be an RFC3339 timestamp: %v", err)},
+		})
+	}
+	return t.UTC(), nil
+}
+
+// page token: base64("<ordered_at RFC3339Nano>|<id>")
+func encodePageToken(orderedAt time.Time, id uuid.UUID) string {
+	raw := orderedAt.Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	ts, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, fmt.Errorf("malformed token")
+	}
+	orderedAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	return orderedAt, id, nil
+}
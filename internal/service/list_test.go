@@ -0,0 +1,37 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseTimeFilter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Time
+		fields []string // 기대하는 BadRequest 위반 필드. 비어있으면 통과해야 한다.
+	}{
+		{"", time.Time{}, nil},
+		{"2025-01-02T03:04:05Z", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), nil},
+		// 오프셋이 있어도 같은 시각의 UTC 로 바뀐다.
+		{"2025-01-02T12:04:05+09:00", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), nil},
+		{"2025-01-02", time.Time{}, []string{"ordered_from"}},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeFilter("ordered_from", tt.value)
+		if len(tt.fields) > 0 {
+			if fields := badRequestFields(t, err); !slices.Equal(fields, tt.fields) {
+				t.Errorf("parseTimeFilter(%q) violated fields = %v, want %v", tt.value, fields, tt.fields)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTimeFilter(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseTimeFilter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+)
+
+// 주문번호의 날짜는 한국 시간 기준이다. scratch 이미지에는 tzdata 가 없어 고정 오프셋을 쓴다.
+var orderNumberZone = time.FixedZone("KST", 9*60*60)
+
+// nextOrderNumber 는 "ORD-YYYYMMDD-NNNNNN" 형식의 주문번호를 만든다.
+// 일련번호는 orders.order_number_seq 에서 받아오므로 날짜와 상관없이 전역적으로 유일하다.
+func nextOrderNumber(ctx context.Context, qtx *postgresql.Queries, now time.Time) (string, error) {
+	seq, err := qtx.NextOrderNumberSeq(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ORD-%s-%06d", now.In(orderNumberZone).Format("20060102"), seq), nil
+}
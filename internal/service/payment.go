@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	"github.com/google/uuid"
+)
+
+// PaymentResult 는 결제 서비스가 알려준 결제 완료 정보다.
+type PaymentResult struct {
+	OrderID   uuid.UUID
+	PaymentID string
+	Amount    int64
+	PaidAt    time.Time
+}
+
+// MarkOrderPaid 는 결제 금액이 주문 총액과 일치하는지 확인한 뒤 주문을 결제 완료 상태로 바꾼다.
+// msg 로 식별되는 메시지가 이미 처리되었다면 아무것도 하지 않는다.
+// 결제 이벤트에는 기대 버전이 없으므로 checkVersion 을 하지 않는다(checkVersion 참고).
+func (s *OrderController) MarkOrderPaid(ctx context.Context, msg MessageRef, p PaymentResult) error {
+	return s.inMessageTx(ctx, msg, func(qtx *postgresql.Queries) error {
+		order, err := qtx.GetOrderForUpdate(ctx, p.OrderID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if order.TotalPrice != p.Amount {
+			return fmt.Errorf("%w: paid %d, order total %d", ErrPaymentAmountMismatch, p.Amount, order.TotalPrice)
+		}
+		if err := checkTransition(OrderStatus(order.Status), OrderStatePaid); err != nil {
+			return err
+		}
+
+		// paid_at 은 TIMESTAMP 라 오프셋이 버려지므로 UTC 로 맞춰 저장한다.
+		err = versionedUpdate(qtx.MarkOrderPaid(ctx, postgresql.MarkOrderPaidParams{
+			ID:        p.OrderID,
+			Status:    string(OrderStatePaid),
+			PaymentID: parseNullString(p.PaymentID),
+			PaidAt:    sql.NullTime{Valid: true, Time: p.PaidAt.UTC()},
+			Version:   order.Version,
+		}))
+		if err != nil {
+			return err
+		}
+		return recordStatusChange(ctx, qtx, p.OrderID, OrderStatus(order.Status), OrderStatePaid,
+			kafkaChange(msg, "payment "+p.PaymentID+" succeeded"))
+	})
+}
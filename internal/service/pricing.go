@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	pb "github.com/escape-ship/protos/gen"
+)
+
+// orderTotals 는 아이템으로부터 서버에서 다시 계산한 주문 금액/수량이다.
+type orderTotals struct {
+	Subtotal   int64 // Σ product_price * quantity
+	Quantity   int32 // Σ quantity
+	TotalPrice int64 // Subtotal + shipping_fee
+}
+
+// computeOrderTotals 는 아이템 금액/수량을 합산한다. int64 금액이나 int32 수량이 넘치면 위반 내역을 반환한다.
+// 음수 가격/수량은 parseInsertOrderRequest 가 따로 거부하므로 오버플로 검사에서는 건너뛴다.
+func computeOrderTotals(items []*pb.InsertOrderItemRequest, shippingFee int32) (orderTotals, []fieldViolation) {
+	var (
+		t          orderTotals
+		violations []fieldViolation
+		quantity   int64
+	)
+	for i, item := range items {
+		if item.ProductPrice > 0 && item.Quantity > 0 && item.ProductPrice > math.MaxInt64/int64(item.Quantity) {
+			violations = append(violations, fieldViolation{
+				Field:       fmt.Sprintf("items[%d].quantity", i),
+				Description: fmt.Sprintf("product_price %d * quantity %d overflows", item.ProductPrice, item.Quantity),
+			})
+			continue
+		}
+		amount := item.ProductPrice * int64(item.Quantity)
+		if amount > 0 && t.Subtotal > math.MaxInt64-amount {
+			violations = append(violations, fieldViolation{Field: "items", Description: "sum of item amounts overflows"})
+			return t, violations
+		}
+		t.Subtotal += amount
+		quantity += int64(item.Quantity)
+	}
+	if quantity > math.MaxInt32 {
+		violations = append(violations, fieldViolation{
+			Field:       "quantity",
+			Description: fmt.Sprintf("sum of item quantities %d exceeds %d", quantity, math.MaxInt32),
+		})
+	}
+	t.Quantity = int32(quantity)
+	if shippingFee > 0 && t.Subtotal > math.MaxInt64-int64(shippingFee) {
+		violations = append(violations, fieldViolation{Field: "total_price", Description: "items + shipping_fee overflows"})
+	}
+	t.TotalPrice = t.Subtotal + int64(shippingFee)
+	return t, violations
+}
+
+// checkOrderTotals 는 클라이언트가 보낸 total_price / quantity 가 서버 계산값과 다르면 위반 내역을 반환한다.
+func checkOrderTotals(req *pb.InsertOrderRequest, t orderTotals) []fieldViolation {
+	var violations []fieldViolation
+	if req.TotalPrice != t.TotalPrice {
+		violations = append(violations, fieldViolation{
+			Field:       "total_price",
+			Description: fmt.Sprintf("expected %d (items %d + shipping_fee %d), got %d", t.TotalPrice, t.Subtotal, req.ShippingFee, req.TotalPrice),
+		})
+	}
+	if req.Quantity != t.Quantity {
+		violations = append(violations, fieldViolation{
+			Field:       "quantity",
+			Description: fmt.Sprintf("expected %d (sum of item quantities), got %d", t.Quantity, req.Quantity),
+		})
+	}
+	return violations
+}
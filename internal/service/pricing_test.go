@@ -0,0 +1,118 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	pb "github.com/escape-ship/protos/gen"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestComputeOrderTotals(t *testing.T) {
+	items := []*pb.InsertOrderItemRequest{
+		{ProductPrice: 1000, Quantity: 2},
+		{ProductPrice: 2500, Quantity: 1},
+	}
+	got, violations := computeOrderTotals(items, 3000)
+	want := orderTotals{Subtotal: 4500, Quantity: 3, TotalPrice: 7500}
+	if got != want || len(violations) > 0 {
+		t.Fatalf("computeOrderTotals() = %+v, %v, want %+v", got, violations, want)
+	}
+}
+
+func validInsertOrderRequest() *pb.InsertOrderRequest {
+	return &pb.InsertOrderRequest{
+		UserId:          "0b6a3b0e-8f4c-4b7e-9a55-3f1f3c2d1e10",
+		PaymentMethod:   "card",
+		ShippingFee:     3000,
+		ShippingAddress: "서울시 강남구",
+		TotalPrice:      7500,
+		Quantity:        3,
+		Items: []*pb.InsertOrderItemRequest{
+			{ProductId: "6f1d9d0c-2b1a-4a53-8b5e-0d9d9c7a6b21", ProductName: "a", ProductPrice: 1000, Quantity: 2},
+			{ProductId: "1c2e3f4a-5b6c-4d7e-8f90-a1b2c3d4e5f6", ProductName: "b", ProductPrice: 2500, Quantity: 1},
+		},
+	}
+}
+
+func TestParseInsertOrderRequestTotals(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*pb.InsertOrderRequest)
+		fields []string // 기대하는 BadRequest 위반 필드. 비어있으면 통과해야 한다.
+	}{
+		{"valid", func(*pb.InsertOrderRequest) {}, nil},
+		{"free shipping", func(r *pb.InsertOrderRequest) { r.ShippingFee, r.TotalPrice = 0, 4500 }, nil},
+		// 아이템 합계가 total_price 와 맞지 않음
+		{"subtotal mismatch", func(r *pb.InsertOrderRequest) { r.Items[0].ProductPrice = 900 }, []string{"total_price"}},
+		{"total mismatch", func(r *pb.InsertOrderRequest) { r.TotalPrice = 7000 }, []string{"total_price"}},
+		// total_price 에 배송비를 빼먹음
+		{"shipping fee not included", func(r *pb.InsertOrderRequest) { r.TotalPrice = 4500 }, []string{"total_price"}},
+		{"shipping fee mismatch", func(r *pb.InsertOrderRequest) { r.ShippingFee = 2500 }, []string{"total_price"}},
+		{"negative shipping fee", func(r *pb.InsertOrderRequest) { r.ShippingFee, r.TotalPrice = -100, 4400 }, []string{"shipping_fee"}},
+		{"quantity mismatch", func(r *pb.InsertOrderRequest) { r.Quantity = 2 }, []string{"quantity"}},
+		{"total and quantity mismatch", func(r *pb.InsertOrderRequest) { r.Items[1].Quantity = 2 }, []string{"total_price", "quantity"}},
+		// 오버플로가 나면 합계 비교 없이 오버플로 위반만 남는다.
+		{"item amount overflow", func(r *pb.InsertOrderRequest) { r.Items[0].ProductPrice, r.Items[0].Quantity = math.MaxInt64/2, 3 }, []string{"items[0].quantity"}},
+		{"subtotal overflow", func(r *pb.InsertOrderRequest) {
+			r.Items[0].ProductPrice, r.Items[1].ProductPrice = math.MaxInt64/2, math.MaxInt64/2
+		}, []string{"items"}},
+		{"total overflow", func(r *pb.InsertOrderRequest) {
+			r.Items = r.Items[:1]
+			r.Items[0].ProductPrice, r.Items[0].Quantity = math.MaxInt64, 1
+		}, []string{"total_price"}},
+		{"quantity overflow", func(r *pb.InsertOrderRequest) {
+			r.Items[0].ProductPrice, r.Items[0].Quantity = 1, math.MaxInt32
+			r.Items[1].ProductPrice, r.Items[1].Quantity = 1, 1
+		}, []string{"quantity"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validInsertOrderRequest()
+			tt.modify(req)
+			in, err := parseInsertOrderRequest(req, nil)
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("parseInsertOrderRequest() error = %v", err)
+				}
+				want, _ := computeOrderTotals(req.Items, req.ShippingFee)
+				if in.Totals != want {
+					t.Fatalf("Totals = %+v, want %+v", in.Totals, want)
+				}
+				return
+			}
+			if got := badRequestFields(t, err); !slices.Equal(got, tt.fields) {
+				t.Fatalf("violated fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+// badRequestFields 는 err 가 BadRequest 상세를 담은 InvalidArgument 인지 확인하고 위반 필드 목록을 반환한다.
+func badRequestFields(t *testing.T, err error) []string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("error = %v, want InvalidArgument", err)
+	}
+	var fields []string
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range br.FieldViolations {
+			if v.Description == "" {
+				t.Errorf("violation of %s has no description", v.Field)
+			}
+			fields = append(fields, v.Field)
+		}
+	}
+	if len(fields) == 0 {
+		t.Fatalf("error %v has no BadRequest field violations", err)
+	}
+	return fields
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	"google.golang.org/grpc/metadata"
+)
+
+// ReadConsistencyHeader 를 ReadConsistencyPrimary 로 보내면 조회 RPC 도 replica 대신 primary 에서 읽는다.
+// 방금 쓴 데이터를 바로 다시 읽어야 할 때(read-your-writes) 사용한다.
+const (
+	ReadConsistencyHeader  = "read-consistency"
+	ReadConsistencyPrimary = "primary"
+)
+
+func readPrimary(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, v := range md.Get(ReadConsistencyHeader) {
+		if strings.EqualFold(v, ReadConsistencyPrimary) {
+			return true
+		}
+	}
+	return false
+}
+
+// readQueries 는 조회 전용 RPC 가 쓰는 Queries 다. 기본은 replica 라서 최근 쓰기가 안 보일 수 있다.
+func (s *OrderController) readQueries(ctx context.Context) *postgresql.Queries {
+	if readPrimary(ctx) {
+		return postgresql.New(s.pg.GetDB())
+	}
+	return postgresql.New(s.pg.GetReadDB())
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+	pb "github.com/escape-ship/protos/gen"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RefundStatus string
+
+const (
+	RefundStateRequested RefundStatus = "requested" // 결제 서비스에 환불 요청됨
+	RefundStateCompleted RefundStatus = "completed" // 환불 완료
+	RefundStateFailed    RefundStatus = "failed"    // 환불 실패
+)
+
+// 전체 환불을 요청할 수 있는 상태. refund_failed 는 실패한 취소 환불을 다시 요청하는 경우다.
+var fullRefundableStates = map[OrderStatus]bool{
+	OrderStatePaid:         true,
+	OrderStatePreparing:    true,
+	OrderStateDelivered:    true,
+	OrderStateRefundFailed: true,
+}
+
+// 부분 환불을 요청할 수 있는 상태. 부분 환불은 주문 상태를 바꾸지 않는다.
+var partialRefundableStates = map[OrderStatus]bool{
+	OrderStatePaid:      true,
+	OrderStatePreparing: true,
+	OrderStateShipped:   true,
+	OrderStateDelivered: true,
+}
+
+// RefundResult 는 결제 서비스가 알려준 환불 처리 결과다.
+type RefundResult struct {
+	RefundID      uuid.UUID
+	Amount        int64
+	Succeeded     bool
+	FailureReason string
+}
+
+// RequestRefund 는 주문의 전체 또는 아이템 단위 부분 환불을 요청한다.
+// 환불 기록을 남기고 결제 서비스에 refund-requested 이벤트를 발행하며,
+// 결과는 payment-refunded / payment-refund-failed 이벤트로 CompleteRefund 에서 반영된다.
+// items 가 비어있으면 남은 금액 전체를 환불한다. expected_version 이 0 이 아니면 주문 버전이 같을 때만
+// 환불을 요청하고, 다르면 Aborted 를 반환한다.
+func (s *OrderController) RequestRefund(ctx context.Context, req *pb.RequestRefundRequest) (*pb.RequestRefundResponse, error) {
+	orderID, err := uuid.Parse(req.OrderId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order ID: %v", err)
+	}
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "refund reason is required")
+	}
+	items := make(map[uuid.UUID]int32, len(req.Items))
+	for _, it := range req.Items {
+		itemID, err := uuid.Parse(it.OrderItemId)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid order item ID %q: %v", it.OrderItemId, err)
+		}
+		if it.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "refund quantity of item %s must be positive", itemID)
+		}
+		items[itemID] += it.Quantity
+	}
+
+	var resp *pb.RequestRefundResponse
+	err = s.inTx(ctx, func(qtx *postgresql.Queries) error {
+		order, err := qtx.GetOrderForUpdate(ctx, orderID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(order, req.ExpectedVersion); err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			from := OrderStatus(order.Status)
+			if !fullRefundableStates[from] {
+				return &TransitionError{From: from, To: OrderStateRefunding}
+			}
+			if err := versionedUpdate(qtx.UpdateOrderStatus(ctx, postgresql.UpdateOrderStatusParams{
+				ID:      orderID,
+				Status:  string(OrderStateRefunding),
+				Version: order.Version,
+			})); err != nil {
+				return err
+			}
+			if err := recordStatusChange(ctx, qtx, orderID, from, OrderStateRefunding, GRPCChange(req.RequestedBy, req.Reason)); err != nil {
+				return err
+			}
+		} else if !partialRefundableStates[OrderStatus(order.Status)] {
+			return status.Errorf(codes.FailedPrecondition, "order in status %s cannot be partially refunded", order.Status)
+		}
+
+		resp, err = requestRefund(ctx, qtx, order, req.Reason, items)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// requestRefund 는 환불 기록과 refund-requested 이벤트를 남긴다. 주문 row 는 호출자가 잠그고 있어야 하며,
+// items 가 비어있으면 아직 환불되지 않은 금액 전체를 환불한다. 전체 환불의 주문 상태 전이는 호출자가 처리한다.
+func requestRefund(ctx context.Context, qtx *postgresql.Queries, order postgresql.OrdersOrder, reason string, items map[uuid.UUID]int32) (*pb.RequestRefundResponse, error) {
+	refunded, err := qtx.GetRefundAmounts(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundID := uuid.New()
+	full := len(items) == 0
+	var (
+		amount      int64
+		itemEvents  []RefundItemEvent
+		itemParams  []postgresql.InsertRefundItemParams
+		orderItems  []postgresql.OrdersOrderItem
+		refundedQty = make(map[uuid.UUID]int32)
+	)
+	if full {
+		// 진행 중인 환불이 실패하면 그 금액도 돌려줘야 하므로, 끝날 때까지 전체 환불 금액을 확정하지 않는다.
+		if refunded.Pending > 0 {
+			return nil, status.Error(codes.FailedPrecondition, "another refund of the order is in progress")
+		}
+		amount = order.TotalPrice - refunded.Completed
+	} else {
+		if orderItems, err = qtx.GetOrderItems(ctx, order.ID); err != nil {
+			return nil, err
+		}
+		rows, err := qtx.GetRefundedItemQuantities(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			refundedQty[r.OrderItemID] = r.Quantity
+		}
+
+		found := 0
+		for _, it := range orderItems {
+			qty, ok := items[it.ID]
+			if !ok {
+				continue
+			}
+			found++
+			if refundedQty[it.ID]+qty > it.Quantity {
+				return nil, status.Errorf(codes.FailedPrecondition,
+					"item %s: refund quantity %d exceeds remaining quantity %d", it.ID, qty, it.Quantity-refundedQty[it.ID])
+			}
+			itemAmount := it.ProductPrice * int64(qty)
+			amount += itemAmount
+			itemParams = append(itemParams, postgresql.InsertRefundItemParams{
+				RefundID:    refundID,
+				OrderItemID: it.ID,
+				Quantity:    qty,
+				Amount:      itemAmount,
+			})
+			itemEvents = append(itemEvents, RefundItemEvent{
+				OrderItemID: it.ID.String(),
+				ProductID:   it.ProductID.String(),
+				Quantity:    qty,
+				Amount:      itemAmount,
+			})
+		}
+		if found != len(items) {
+			return nil, status.Error(codes.InvalidArgument, "refund contains items that do not belong to the order")
+		}
+		if remaining := order.TotalPrice - refunded.Completed - refunded.Pending; amount > remaining {
+			return nil, status.Errorf(codes.FailedPrecondition, "refund amount %d exceeds remaining amount %d", amount, remaining)
+		}
+	}
+	if amount <= 0 {
+		return nil, status.Error(codes.FailedPrecondition, "nothing left to refund")
+	}
+
+	err = qtx.InsertRefund(ctx, postgresql.InsertRefundParams{
+		ID:                  refundID,
+		OrderID:             order.ID,
+		Status:              string(RefundStateRequested),
+		Amount:              amount,
+		FullRefund:          full,
+		PreviousOrderStatus: order.Status,
+		Reason:              parseNullString(reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range itemParams {
+		if err := qtx.InsertRefundItem(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	err = enqueueEvent(ctx, qtx, TopicRefundRequested, order.ID, RefundRequestedEvent{
+		Version:    orderEventVersion,
+		RefundID:   refundID.String(),
+		OrderID:    order.ID.String(),
+		PaymentID:  order.PaymentID.String,
+		Amount:     amount,
+		Reason:     reason,
+		Items:      itemEvents,
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RequestRefundResponse{RefundId: refundID.String(), Amount: amount}, nil
+}
+
+// CompleteRefund 는 결제 서비스의 환불 결과를 반영한다. 환불은 completed 또는 failed 가 되고,
+// 전체 환불이면 주문 상태도 바꾼다.
+//   - 취소 환불(cancelled_at 이 있는 주문): 성공하면 cancelled, 실패하면 refund_failed.
+//     취소는 되돌리지 않으며 refund_failed 주문은 RequestRefund 로 다시 환불을 요청한다.
+//   - 그 외 전체 환불: 성공하면 refunded, 실패하면 환불 요청 전 상태로 되돌린다.
+//
+// 환불 결과 이벤트에는 기대 버전이 없으므로 checkVersion 을 하지 않는다(checkVersion 참고).
+func (s *OrderController) CompleteRefund(ctx context.Context, msg MessageRef, r RefundResult) error {
+	return s.inMessageTx(ctx, msg, func(qtx *postgresql.Queries) error {
+		refund, err := qtx.GetRefundForUpdate(ctx, r.RefundID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRefundNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if RefundStatus(refund.Status) != RefundStateRequested {
+			return fmt.Errorf("%w: refund %s is %s", ErrRefundNotPending, refund.ID, refund.Status)
+		}
+		if r.Succeeded && r.Amount != refund.Amount {
+			return fmt.Errorf("%w: refunded %d, requested %d", ErrRefundAmountMismatch, r.Amount, refund.Amount)
+		}
+
+		next := RefundStateCompleted
+		if !r.Succeeded {
+			next = RefundStateFailed
+		}
+		err = qtx.UpdateRefundStatus(ctx, postgresql.UpdateRefundStatusParams{
+			ID:            refund.ID,
+			Status:        string(next),
+			FailureReason: parseNullString(r.FailureReason),
+		})
+		if err != nil {
+			return err
+		}
+		if !refund.FullRefund {
+			return nil
+		}
+
+		order, err := qtx.GetOrderForUpdate(ctx, refund.OrderID)
+		if err != nil {
+			return err
+		}
+		from := OrderStatus(order.Status)
+		cancellation := order.CancelledAt.Valid
+		var to OrderStatus
+		switch {
+		case cancellation && r.Succeeded:
+			to = OrderStateCancelled
+		case cancellation:
+			to = OrderStateRefundFailed
+		case r.Succeeded:
+			to = OrderStateRefunded
+		default:
+			to = OrderStatus(refund.PreviousOrderStatus)
+		}
+		if err := checkTransition(from, to); err != nil {
+			return err
+		}
+		err = versionedUpdate(qtx.UpdateOrderStatus(ctx, postgresql.UpdateOrderStatusParams{
+			ID:      order.ID,
+			Status:  string(to),
+			Version: order.Version,
+		}))
+		if err != nil {
+			return err
+		}
+		reason := "refund " + refund.ID.String() + " completed"
+		if !r.Succeeded {
+			reason = "refund " + refund.ID.String() + " failed: " + r.FailureReason
+		}
+		return recordStatusChange(ctx, qtx, order.ID, from, to, kafkaChange(msg, reason))
+	})
+}
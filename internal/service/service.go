@@ -3,194 +3,273 @@ package service
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
 	"github.com/escape-ship/ordersrv/pkg/postgres"
 	pb "github.com/escape-ship/protos/gen"
 	"github.com/google/uuid"
-	"github.com/sqlc-dev/pqtype"
 )
 
 type OrderController struct {
 	pb.UnimplementedOrderServiceServer
-	pg postgres.DBEngine
+	pg             postgres.DBEngine
+	paymentMethods map[string]bool
 }
 
-func NewOrderController(pg postgres.DBEngine) *OrderController {
-	return &OrderController{
+type Option func(*OrderController)
+
+// WithPaymentMethods 는 InsertOrder 가 받을 결제 수단을 제한한다. 주지 않으면 결제 수단을 검사하지 않는다.
+func WithPaymentMethods(methods ...string) Option {
+	return func(s *OrderController) {
+		if len(methods) == 0 {
+			s.paymentMethods = nil
+			return
+		}
+		s.paymentMethods = make(map[string]bool, len(methods))
+		for _, m := range methods {
+			s.paymentMethods[m] = true
+		}
+	}
+}
+
+func NewOrderController(pg postgres.DBEngine, opts ...Option) *OrderController {
+	s := &OrderController{
 		pg: pg,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *OrderController) InsertOrder(ctx context.Context, req *pb.InsertOrderRequest) (*pb.InsertOrderResponse, error) {
-	db := s.pg.GetDB()
-	querier := postgresql.New(db)
-
-	tx, err := db.Begin()
+	in, err := parseInsertOrderRequest(req, s.paymentMethods)
 	if err != nil {
 		return nil, err
 	}
-	qtx := querier.WithTx(tx)
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		} else {
-			tx.Commit()
-		}
-	}()
-	userId, err := uuid.Parse(req.UserId)
-	if err != nil {
-		fmt.Println("invalid UUID:", err)
-		return nil, fmt.Errorf("invalid user ID: %w", err)
-	}
-	orderID := uuid.New()
-	orderParams := postgresql.InsertOrderParams{
-		ID:              orderID,
-		UserID:          userId,
-		OrderNumber:     req.OrderNumber,
-		Status:          string(OrderStateReceived),
-		TotalPrice:      req.TotalPrice,
-		Quantity:        req.Quantity,
-		PaymentMethod:   req.PaymentMethod,
-		ShippingFee:     req.ShippingFee,
-		ShippingAddress: req.ShippingAddress,
-		Column10:        nil, // ordered_at (nil이면 CURRENT_TIMESTAMP)
-		PaidAt:          parseNullTime(req.PaidAt),
-		Memo:            parseNullString(req.Memo),
-	}
-	_, err = qtx.InsertOrder(ctx, orderParams)
+	key, err := idempotencyKey(ctx)
 	if err != nil {
 		return nil, err
 	}
+	var fingerprint string
+	if key != "" {
+		if fingerprint, err = requestFingerprint(req); err != nil {
+			return nil, err
+		}
+	}
 
-	for _, item := range req.Items {
-		itemID := uuid.New()
+	var resp struct {
+		orderID     uuid.UUID
+		orderNumber string
+	}
+	err = s.inTx(ctx, func(qtx *postgresql.Queries) error {
+		orderID := uuid.New()
 
-		var options map[string]interface{}
-		if err := json.Unmarshal([]byte(item.ProductOptions), &options); err != nil {
-			log.Printf("invalid product_options for item %v: %v", item.ProductId, err)
-			continue
+		// 같은 key 로 재시도된 요청이면 처음 만든 주문을 돌려준다.
+		if key != "" {
+			existing, replay, err := claimIdempotencyKey(ctx, qtx, in.UserID, key, fingerprint, orderID)
+			if err != nil {
+				return err
+			}
+			if replay {
+				order, err := qtx.GetOrder(ctx, existing)
+				if err != nil {
+					return err
+				}
+				resp.orderID, resp.orderNumber = existing, order.OrderNumber
+				return nil
+			}
 		}
 
-		// 👉 map → JSON → RawMessage
-		rawOptions, err := json.Marshal(options)
+		// 주문번호는 클라이언트 값을 쓰지 않고 서버에서 만든다.
+		orderNumber, err := nextOrderNumber(ctx, qtx, time.Now())
 		if err != nil {
-			log.Printf("failed to marshal product_options for item %v: %v", item.ProductId, err)
-			continue
-		}
-
-		itemParams := postgresql.InsertOrderItemParams{
-			ID:           itemID,
-			OrderID:      orderID,
-			ProductID:    uuid.MustParse(item.ProductId),
-			ProductName:  item.ProductName,
-			ProductPrice: item.ProductPrice,
-			ProductOptions: pqtype.NullRawMessage{
-				RawMessage: rawOptions,
-				Valid:      true,
-			},
-			Quantity: item.Quantity,
-		}
-		err = qtx.InsertOrderItem(ctx, itemParams)
+			return err
+		}
+
+		orderParams := postgresql.InsertOrderParams{
+			ID:              orderID,
+			UserID:          in.UserID,
+			OrderNumber:     orderNumber,
+			Status:          string(OrderStateReceived),
+			TotalPrice:      in.Totals.TotalPrice,
+			Quantity:        in.Totals.Quantity,
+			PaymentMethod:   req.PaymentMethod,
+			ShippingFee:     req.ShippingFee,
+			ShippingAddress: req.ShippingAddress,
+			Column10:        nil, // ordered_at (nil이면 CURRENT_TIMESTAMP)
+			PaidAt:          in.PaidAt,
+			Memo:            parseNullString(req.Memo),
+		}
+		if _, err := qtx.InsertOrder(ctx, orderParams); err != nil {
+			return err
+		}
+
+		for _, item := range in.Items {
+			err := qtx.InsertOrderItem(ctx, postgresql.InsertOrderItemParams{
+				ID:             uuid.New(),
+				OrderID:        orderID,
+				ProductID:      item.ProductID,
+				ProductName:    item.ProductName,
+				ProductPrice:   item.ProductPrice,
+				ProductOptions: item.ProductOptions,
+				Quantity:       item.Quantity,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to insert order item %v: %w", item.ProductID, err)
+			}
+		}
+
+		err = insertStatusHistory(ctx, qtx, orderID, "", OrderStateReceived, GRPCChange(in.UserID.String(), "order created"))
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert order item %v: %w", item.ProductId, err)
+			return err
+		}
+
+		err = enqueueEvent(ctx, qtx, TopicOrderCreated, orderID, OrderCreatedEvent{
+			Version:     orderEventVersion,
+			OrderID:     orderID.String(),
+			UserID:      in.UserID.String(),
+			OrderNumber: orderNumber,
+			Status:      string(OrderStateReceived),
+			TotalPrice:  in.Totals.TotalPrice,
+			Quantity:    in.Totals.Quantity,
+			OccurredAt:  time.Now().UTC(),
+		})
+		if err != nil {
+			return err
 		}
-	}
 
-	return &pb.InsertOrderResponse{Id: orderID.String()}, nil
+		resp.orderID, resp.orderNumber = orderID, orderNumber
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.InsertOrderResponse{Id: resp.orderID.String(), OrderNumber: resp.orderNumber}, nil
 }
 
 func (s *OrderController) GetAllOrders(ctx context.Context, req *pb.GetAllOrdersRequest) (*pb.GetAllOrdersResponse, error) {
-	querier := postgresql.New(s.pg.GetDB())
+	querier := s.readQueries(ctx)
 
 	orders, err := querier.GetAllOrders(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var respOrders []*pb.Order
-	for _, o := range orders {
-		items, err := querier.GetOrderItems(ctx, o.ID)
-		if err != nil {
-			return nil, err
-		}
-		var pbItems []*pb.OrderItem
-		for _, it := range items {
-			pbItems = append(pbItems, &pb.OrderItem{
-				Id:           it.ID.String(),
-				OrderId:      it.OrderID.String(),
-				ProductId:    it.ProductID.String(),
-				ProductName:  it.ProductName,
-				ProductPrice: it.ProductPrice,
-				Quantity:     it.Quantity,
-			})
-		}
-		respOrders = append(respOrders, &pb.Order{
-			Id:              o.ID.String(),
-			UserId:          o.UserID.String(),
-			OrderNumber:     o.OrderNumber,
-			Status:          o.Status,
-			TotalPrice:      o.TotalPrice,
-			Quantity:        o.Quantity,
-			PaymentMethod:   o.PaymentMethod,
-			ShippingFee:     o.ShippingFee,
-			ShippingAddress: o.ShippingAddress,
-			OrderedAt:       o.OrderedAt.Format(time.RFC3339),
-			PaidAt:          o.PaidAt.Time.Format(time.RFC3339),
-			Memo:            o.Memo.String,
-			Items:           pbItems,
-		})
+	itemsByOrder, err := loadOrderItems(ctx, querier, orders)
+	if err != nil {
+		return nil, err
 	}
-	return &pb.GetAllOrdersResponse{Orders: respOrders}, nil
+	return &pb.GetAllOrdersResponse{Orders: toPBOrders(orders, itemsByOrder)}, nil
 }
 
 // kafka 메시지를 받았을때 order의 status를 변경하는 함수
-func (s *OrderController) UpdateOrderStatus(ctx context.Context, orderID string, status OrderStatus) error {
-	db := s.pg.GetDB()
-	querier := postgresql.New(db)
-
-	tx, err := db.Begin()
+// 주문 row 를 잠근 뒤 상태 전이 테이블을 확인하고, 허용되지 않으면 ErrInvalidTransition 을 반환한다.
+// expectedVersion 이 0 이 아니고 현재 버전과 다르면 ErrVersionConflict 를 반환한다.
+func (s *OrderController) UpdateOrderStatus(ctx context.Context, orderID string, status OrderStatus, expectedVersion int32, change StatusChange) error {
+	orderUUID, err := uuid.Parse(orderID)
 	if err != nil {
 		return err
 	}
-	qtx := querier.WithTx(tx)
-	defer func() {
+
+	return s.inTx(ctx, func(qtx *postgresql.Queries) error {
+		order, err := qtx.GetOrderForUpdate(ctx, orderUUID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrderNotFound
+		}
 		if err != nil {
-			tx.Rollback()
-		} else {
-			tx.Commit()
+			return err
+		}
+		if err := checkVersion(order, expectedVersion); err != nil {
+			return err
+		}
+		if err := checkTransition(OrderStatus(order.Status), status); err != nil {
+			return err
 		}
-	}()
 
-	orderUUID, err := uuid.Parse(orderID)
-	if err != nil {
-		return err
-	}
+		// 주문 상태 업데이트
+		err = versionedUpdate(qtx.UpdateOrderStatus(ctx, postgresql.UpdateOrderStatusParams{
+			ID:      orderUUID,
+			Status:  string(status),
+			Version: order.Version,
+		}))
+		if err != nil {
+			return err
+		}
+		return recordStatusChange(ctx, qtx, orderUUID, OrderStatus(order.Status), status, change)
+	})
+}
 
-	// 주문 상태 업데이트
-	err = qtx.UpdateOrderStatus(ctx, postgresql.UpdateOrderStatusParams{
-		ID:     orderUUID,
-		Status: string(status),
+// inTx 는 fn 을 하나의 트랜잭션 안에서 실행한다. fn 이 에러를 반환하면 롤백하고, 아니면 커밋 에러까지 반환한다.
+// 직렬화 실패/데드락이면 fn 이 처음부터 다시 실행되므로 fn 은 트랜잭션 밖에 부수효과를 남기면 안 된다.
+func (s *OrderController) inTx(ctx context.Context, fn func(qtx *postgresql.Queries) error) error {
+	return s.pg.WithTx(ctx, nil, func(tx *sql.Tx) error {
+		return fn(postgresql.New(tx))
 	})
+}
+
+// loadOrderItems 는 orders 의 아이템을 한 번의 쿼리로 읽어와 주문 ID 별로 묶는다.
+// 주문 수와 상관없이 쿼리는 한 번만 실행된다.
+func loadOrderItems(ctx context.Context, querier *postgresql.Queries, orders []postgresql.OrdersOrder) (map[uuid.UUID][]postgresql.OrdersOrderItem, error) {
+	itemsByOrder := make(map[uuid.UUID][]postgresql.OrdersOrderItem, len(orders))
+	if len(orders) == 0 {
+		return itemsByOrder, nil
+	}
+	ids := make([]uuid.UUID, len(orders))
+	for i, o := range orders {
+		ids[i] = o.ID
+	}
+	items, err := querier.GetOrderItemsByOrderIDs(ctx, ids)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	for _, it := range items {
+		itemsByOrder[it.OrderID] = append(itemsByOrder[it.OrderID], it)
+	}
+	return itemsByOrder, nil
+}
 
-	return nil
+func toPBOrders(orders []postgresql.OrdersOrder, itemsByOrder map[uuid.UUID][]postgresql.OrdersOrderItem) []*pb.Order {
+	res := make([]*pb.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, toPBOrder(o, itemsByOrder[o.ID]))
+	}
+	return res
 }
 
-func parseNullTime(s string) sql.NullTime {
-	if s == "" {
-		return sql.NullTime{Valid: false}
+func toPBOrder(o postgresql.OrdersOrder, items []postgresql.OrdersOrderItem) *pb.Order {
+	var pbItems []*pb.OrderItem
+	for _, it := range items {
+		pbItem := &pb.OrderItem{
+			Id:           it.ID.String(),
+			OrderId:      it.OrderID.String(),
+			ProductId:    it.ProductID.String(),
+			ProductName:  it.ProductName,
+			ProductPrice: it.ProductPrice,
+			Quantity:     it.Quantity,
+		}
+		if it.ProductOptions.Valid {
+			pbItem.ProductOptions = string(it.ProductOptions.RawMessage)
+		}
+		pbItems = append(pbItems, pbItem)
 	}
-	t, err := time.Parse(time.RFC3339, s)
-	if err != nil {
-		return sql.NullTime{Valid: false}
+	return &pb.Order{
+		Id:              o.ID.String(),
+		UserId:          o.UserID.String(),
+		OrderNumber:     o.OrderNumber,
+		Status:          o.Status,
+		TotalPrice:      o.TotalPrice,
+		Quantity:        o.Quantity,
+		PaymentMethod:   o.PaymentMethod,
+		ShippingFee:     o.ShippingFee,
+		ShippingAddress: o.ShippingAddress,
+		OrderedAt:       o.OrderedAt.Format(time.RFC3339),
+		PaidAt:          o.PaidAt.Time.Format(time.RFC3339),
+		Memo:            o.Memo.String,
+		Items:           pbItems,
 	}
-	return sql.NullTime{Valid: true, Time: t}
 }
 
 func parseNullString(s string) sql.NullString {
@@ -199,3 +278,10 @@ func parseNullString(s string) sql.NullString {
 	}
 	return sql.NullString{Valid: true, String: s}
 }
+
+func parseNullTimeValue(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Valid: true, Time: t}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/escape-ship/ordersrv/pkg/postgres"
+	"github.com/google/uuid"
+)
+
+// countingDriver 는 실행된 쿼리 수를 세는 database/sql 드라이버다.
+// GetAllOrders 는 orders 개의 주문을, GetOrderItemsByOrderIDs 는 주문마다 아이템 하나를 돌려준다.
+type countingDriver struct {
+	mu      sync.Mutex
+	orders  int
+	queries atomic.Int64
+}
+
+var (
+	_countingDriver     = &countingDriver{}
+	_countingDriverOnce sync.Once
+)
+
+func (d *countingDriver) Open(string) (driver.Conn, error) { return &countingConn{d: d}, nil }
+
+type countingConn struct{ d *countingDriver }
+
+func (c *countingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("countingConn: prepare not supported")
+}
+func (c *countingConn) Close() error { return nil }
+func (c *countingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("countingConn: tx not supported")
+}
+
+func (c *countingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.d.queries.Add(1)
+	c.d.mu.Lock()
+	n := c.d.orders
+	c.d.mu.Unlock()
+
+	now := time.Now()
+	switch {
+	case strings.Contains(query, "-- name: GetAllOrders "):
+		rows := &countingRows{cols: []string{
+			"id", "user_id", "order_number", "status", "total_price", "quantity", "payment_method",
+			"shipping_fee", "shipping_address", "ordered_at", "paid_at", "memo", "payment_id",
+			"cancelled_at", "cancelled_by", "cancel_reason", "updated_at", "version",
+		}}
+		for i := 0; i < n; i++ {
+			rows.data = append(rows.data, []driver.Value{
+				orderUUID(i).String(), uuid.NewString(), fmt.Sprintf("ORD-%d", i), string(OrderStateReceived),
+				int64(1000), int64(1), "card", int64(0), "addr", now, nil, nil, nil, nil, nil, nil, now, int64(1),
+			})
+		}
+		return rows, nil
+	case strings.Contains(query, "-- name: GetOrderItemsByOrderIDs "):
+		rows := &countingRows{cols: []string{
+			"id", "order_id", "product_id", "product_name", "product_price", "product_options", "quantity",
+		}}
+		for i := 0; i < n; i++ {
+			rows.data = append(rows.data, []driver.Value{
+				uuid.NewString(), orderUUID(i).String(), uuid.NewString(), "product", int64(1000), nil, int64(1),
+			})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("countingConn: unexpected query %q", query)
+}
+
+type countingRows struct {
+	cols []string
+	data [][]driver.Value
+	pos  int
+}
+
+func (r *countingRows) Columns() []string { return r.cols }
+func (r *countingRows) Close() error      { return nil }
+func (r *countingRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.pos])
+	r.pos++
+	return nil
+}
+
+func orderUUID(i int) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprint(i)))
+}
+
+// countingEngine 은 countingDriver 로 연 *sql.DB 를 primary/replica 로 쓰는 DBEngine 이다.
+type countingEngine struct{ db *sql.DB }
+
+func (e *countingEngine) Configure(...postgres.Option) postgres.DBEngine { return e }
+func (e *countingEngine) GetDB() *sql.DB                                 { return e.db }
+func (e *countingEngine) GetReadDB() *sql.DB                             { return e.db }
+func (e *countingEngine) Ping(ctx context.Context) error                 { return e.db.PingContext(ctx) }
+func (e *countingEngine) Close()                                         { e.db.Close() }
+func (e *countingEngine) WithTx(context.Context, *sql.TxOptions, func(*sql.Tx) error) error {
+	return errors.New("countingEngine: tx not supported")
+}
+
+func TestGetAllOrdersQueryCount(t *testing.T) {
+	_countingDriverOnce.Do(func() { sql.Register("counting", _countingDriver) })
+	db, err := sql.Open("counting", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewOrderController(&countingEngine{db: db})
+	t.Cleanup(s.pg.Close)
+
+	counts := make(map[int]int64)
+	for _, n := range []int{1, 1000} {
+		_countingDriver.mu.Lock()
+		_countingDriver.orders = n
+		_countingDriver.mu.Unlock()
+		before := _countingDriver.queries.Load()
+
+		resp, err := s.GetAllOrders(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("N=%d: GetAllOrders() error = %v", n, err)
+		}
+		if len(resp.Orders) != n {
+			t.Fatalf("N=%d: got %d orders", n, len(resp.Orders))
+		}
+		for _, o := range resp.Orders {
+			if len(o.Items) != 1 {
+				t.Fatalf("N=%d: order %s has %d items, want 1", n, o.Id, len(o.Items))
+			}
+		}
+		counts[n] = _countingDriver.queries.Load() - before
+	}
+	if counts[1] != counts[1000] {
+		t.Errorf("query count grows with N: N=1 -> %d, N=1000 -> %d", counts[1], counts[1000])
+	}
+	if counts[1] != 2 {
+		t.Errorf("query count = %d, want 2 (orders + items)", counts[1])
+	}
+}
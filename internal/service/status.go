@@ -1,5 +1,13 @@
 package service
 
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
 type OrderStatus string
 
 const (
@@ -11,4 +19,68 @@ const (
 	OrderStateCancelled OrderStatus = "cancelled" // 주문 취소됨
 	OrderStateRefunding OrderStatus = "refunding" // 환불 처리중
 	OrderStateRefunded  OrderStatus = "refunded"  // 환불 완료
+
+	OrderStateRefundFailed OrderStatus = "refund_failed" // 취소 환불 실패 (환불 재요청 필요)
 )
+
+// 허용되는 상태 전이 테이블. 여기에 없는 전이는 모두 거부된다.
+//
+// refunding 에서 나가는 전이는 CompleteRefund 가 결정한다. 취소 환불은 cancelled / refund_failed 로,
+// 그 외 전체 환불은 성공하면 refunded, 실패하면 환불을 요청할 수 있었던 상태(paid/preparing/delivered)로 돌아간다.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStateReceived:     {OrderStatePaid, OrderStateCancelled},
+	OrderStatePaid:         {OrderStatePreparing, OrderStateCancelled, OrderStateRefunding},
+	OrderStatePreparing:    {OrderStateShipped, OrderStateCancelled, OrderStateRefunding},
+	OrderStateShipped:      {OrderStateDelivered},
+	OrderStateDelivered:    {OrderStateRefunding},
+	OrderStateRefunding:    {OrderStateRefunded, OrderStateCancelled, OrderStateRefundFailed, OrderStatePaid, OrderStatePreparing, OrderStateDelivered},
+	OrderStateRefundFailed: {OrderStateRefunding},
+	OrderStateCancelled:    {},
+	OrderStateRefunded:     {},
+}
+
+// ErrInvalidTransition 은 상태 전이 테이블에 없는 전이를 시도했을 때 반환된다.
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// TransitionError 는 거부된 전이의 출발/도착 상태를 담는다.
+// errors.Is(err, ErrInvalidTransition) 로 확인할 수 있고 gRPC FailedPrecondition 으로 변환된다.
+type TransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrInvalidTransition, e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+func (e *TransitionError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// Valid 는 정의된 주문 상태인지 확인한다.
+func (s OrderStatus) Valid() bool {
+	_, ok := orderTransitions[s]
+	return ok
+}
+
+// CanTransitionTo 는 s 에서 next 로의 전이가 허용되는지 확인한다.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransition 은 전이가 허용되지 않으면 *TransitionError 를 반환한다.
+func checkTransition(from, to OrderStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckTransition(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		ok       bool
+	}{
+		{OrderStateReceived, OrderStatePaid, true},
+		{OrderStateReceived, OrderStateCancelled, true},
+		{OrderStateReceived, OrderStateShipped, false},
+		{OrderStateReceived, OrderStateRefunding, false},
+		{OrderStatePaid, OrderStatePreparing, true},
+		{OrderStatePaid, OrderStateCancelled, true},
+		{OrderStatePaid, OrderStateRefunding, true},
+		{OrderStatePaid, OrderStateReceived, false},
+		{OrderStatePreparing, OrderStateShipped, true},
+		{OrderStatePreparing, OrderStateDelivered, false},
+		{OrderStateShipped, OrderStateDelivered, true},
+		{OrderStateShipped, OrderStateCancelled, false},
+		{OrderStateShipped, OrderStateRefunding, false},
+		{OrderStateDelivered, OrderStateRefunding, true},
+		{OrderStateDelivered, OrderStateShipped, false},
+		{OrderStateRefunding, OrderStateRefunded, true},
+		{OrderStateRefunding, OrderStateCancelled, true},
+		{OrderStateRefunding, OrderStateRefundFailed, true},
+		{OrderStateRefunding, OrderStatePaid, true},
+		{OrderStateRefunding, OrderStatePreparing, true},
+		{OrderStateRefunding, OrderStateDelivered, true},
+		{OrderStateRefunding, OrderStateReceived, false},
+		{OrderStateRefunding, OrderStateShipped, false},
+		{OrderStateRefundFailed, OrderStateRefunding, true},
+		{OrderStateRefundFailed, OrderStatePaid, false},
+		{OrderStateRefundFailed, OrderStateCancelled, false},
+		{OrderStateCancelled, OrderStateReceived, false},
+		{OrderStateCancelled, OrderStatePaid, false},
+		{OrderStateRefunded, OrderStatePaid, false},
+		{OrderStatePaid, OrderStatePaid, false},
+		{OrderStatus("unknown"), OrderStatePaid, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := checkTransition(tt.from, tt.to)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("checkTransition() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("checkTransition() = %v, want ErrInvalidTransition", err)
+			}
+			var te *TransitionError
+			if !errors.As(err, &te) || te.From != tt.from || te.To != tt.to {
+				t.Fatalf("checkTransition() = %#v, want TransitionError{%s, %s}", err, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestTransitionErrorStatus(t *testing.T) {
+	err := checkTransition(OrderStateCancelled, OrderStatePaid)
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("status.FromError(%v) not ok", err)
+	}
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("code = %s, want FailedPrecondition", st.Code())
+	}
+	if want := "invalid order status transition: cancelled -> paid"; st.Message() != want {
+		t.Fatalf("message = %q, want %q", st.Message(), want)
+	}
+}
+
+func TestOrderStatusValid(t *testing.T) {
+	for status := range orderTransitions {
+		if !status.Valid() {
+			t.Errorf("%s.Valid() = false", status)
+		}
+		// 전이 대상도 모두 정의된 상태여야 한다.
+		for _, next := range orderTransitions[status] {
+			if !next.Valid() {
+				t.Errorf("transition %s -> %s targets an undefined status", status, next)
+			}
+		}
+	}
+	if OrderStatus("shipping").Valid() {
+		t.Error(`OrderStatus("shipping").Valid() = true`)
+	}
+}
+
+func TestFullRefundRollbackTransitions(t *testing.T) {
+	// 전체 환불이 실패하면 환불 요청 전 상태로 돌아가야 하므로, 전체 환불 가능한 상태마다 복구 전이가 있어야 한다.
+	for from := range fullRefundableStates {
+		if !from.CanTransitionTo(OrderStateRefunding) {
+			t.Errorf("%s -> refunding is not allowed", from)
+		}
+		if from == OrderStateRefundFailed {
+			continue
+		}
+		if !OrderStateRefunding.CanTransitionTo(from) {
+			t.Errorf("refunding -> %s is not allowed", from)
+		}
+	}
+}
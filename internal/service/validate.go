@@ -0,0 +1,129 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	pb "github.com/escape-ship/protos/gen"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const (
+	_maxShippingAddressLen = 500
+	_maxMemoLen            = 1000
+)
+
+// insertOrderInput 은 검증을 통과한 InsertOrderRequest 의 파싱 결과다.
+type insertOrderInput struct {
+	UserID uuid.UUID
+	PaidAt sql.NullTime
+	Items  []insertOrderItem
+	Totals orderTotals
+}
+
+type insertOrderItem struct {
+	ProductID      uuid.UUID
+	ProductName    string
+	ProductPrice   int64
+	ProductOptions pqtype.NullRawMessage
+	Quantity       int32
+}
+
+// parseInsertOrderRequest 는 요청의 모든 필드를 검사해 위반 내역을 한 번에 모아
+// errdetails.BadRequest 가 담긴 InvalidArgument 로 반환한다. 통과하면 파싱된 값을 돌려준다.
+// paymentMethods 가 비어있으면 결제 수단은 빈 값만 거부한다.
+func parseInsertOrderRequest(req *pb.InsertOrderRequest, paymentMethods map[string]bool) (*insertOrderInput, error) {
+	var (
+		in         insertOrderInput
+		violations []fieldViolation
+		err        error
+	)
+	violate := func(field, format string, args ...any) {
+		violations = append(violations, fieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	if in.UserID, err = uuid.Parse(req.UserId); err != nil {
+		violate("user_id", "must be a UUID: %v", err)
+	}
+	switch {
+	case req.PaymentMethod == "":
+		violate("payment_method", "is required")
+	case len(paymentMethods) > 0 && !paymentMethods[req.PaymentMethod]:
+		violate("payment_method", "unknown payment method %q", req.PaymentMethod)
+	}
+	if req.ShippingFee < 0 {
+		violate("shipping_fee", "must not be negative")
+	}
+	switch n := utf8.RuneCountInString(req.ShippingAddress); {
+	case n == 0:
+		violate("shipping_address", "is required")
+	case n > _maxShippingAddressLen:
+		violate("shipping_address", "must be at most %d characters", _maxShippingAddressLen)
+	}
+	if utf8.RuneCountInString(req.Memo) > _maxMemoLen {
+		violate("memo", "must be at most %d characters", _maxMemoLen)
+	}
+	if req.PaidAt != "" {
+		t, err := time.Parse(time.RFC3339, req.PaidAt)
+		if err != nil {
+			violate("paid_at", "must be an RFC3339 timestamp")
+		}
+		in.PaidAt = sql.NullTime{Valid: err == nil, Time: t.UTC()}
+	}
+
+	if len(req.Items) == 0 {
+		violate("items", "at least one item is required")
+	}
+	for i, item := range req.Items {
+		field := func(name string) string { return fmt.Sprintf("items[%d].%s", i, name) }
+		parsed := insertOrderItem{
+			ProductName:  item.ProductName,
+			ProductPrice: item.ProductPrice,
+			Quantity:     item.Quantity,
+		}
+		if parsed.ProductID, err = uuid.Parse(item.ProductId); err != nil {
+			violate(field("product_id"), "must be a UUID: %v", err)
+		}
+		if item.ProductName == "" {
+			violate(field("product_name"), "is required")
+		}
+		if item.ProductPrice < 0 {
+			violate(field("product_price"), "must not be negative")
+		}
+		if item.Quantity <= 0 {
+			violate(field("quantity"), "must be positive")
+		}
+		if item.ProductOptions != "" {
+			var options map[string]any
+			switch err := json.Unmarshal([]byte(item.ProductOptions), &options); {
+			case err != nil:
+				violate(field("product_options"), "must be a JSON object: %v", err)
+			case options == nil:
+				// "null" 은 에러 없이 nil map 이 되지만 JSON null 이 그대로 저장되므로 받지 않는다.
+				violate(field("product_options"), "must be a JSON object, not null")
+			default:
+				parsed.ProductOptions = pqtype.NullRawMessage{RawMessage: json.RawMessage(item.ProductOptions), Valid: true}
+			}
+		}
+		in.Items = append(in.Items, parsed)
+	}
+
+	// 금액/수량은 클라이언트 값을 믿지 않고 아이템으로부터 다시 계산한다.
+	// 계산 중 오버플로가 나면 비교할 값이 없으므로 오버플로 위반만 남긴다.
+	totals, overflows := computeOrderTotals(req.Items, req.ShippingFee)
+	if len(overflows) > 0 {
+		violations = append(violations, overflows...)
+	} else {
+		in.Totals = totals
+		violations = append(violations, checkOrderTotals(req, totals)...)
+	}
+
+	if len(violations) > 0 {
+		return nil, invalidArgument("invalid InsertOrderRequest", violations)
+	}
+	return &in, nil
+}
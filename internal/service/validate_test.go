@@ -0,0 +1,109 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/escape-ship/protos/gen"
+)
+
+func TestParseInsertOrderRequestPaymentMethod(t *testing.T) {
+	allowed := map[string]bool{"card": true, "kakaopay": true}
+	tests := []struct {
+		name    string
+		method  string
+		allowed map[string]bool
+		ok      bool
+	}{
+		{"allowed", "card", allowed, true},
+		{"not allowed", "bitcoin", allowed, false},
+		{"no allowlist", "bitcoin", nil, true},
+		{"empty", "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validInsertOrderRequest()
+			req.PaymentMethod = tt.method
+			_, err := parseInsertOrderRequest(req, tt.allowed)
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("parseInsertOrderRequest() error = %v", err)
+				}
+				return
+			}
+			if got := badRequestFields(t, err); !slices.Equal(got, []string{"payment_method"}) {
+				t.Fatalf("violated fields = %v, want [payment_method]", got)
+			}
+		})
+	}
+}
+
+func TestParseInsertOrderRequestFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*pb.InsertOrderRequest)
+		fields []string // 기대하는 BadRequest 위반 필드. 비어있으면 통과해야 한다.
+	}{
+		{"valid", func(*pb.InsertOrderRequest) {}, nil},
+		{"bad user_id", func(r *pb.InsertOrderRequest) { r.UserId = "user-1" }, []string{"user_id"}},
+		// 예전에는 uuid.MustParse 가 panic 했다.
+		{"bad product_id", func(r *pb.InsertOrderRequest) { r.Items[0].ProductId = "123" }, []string{"items[0].product_id"}},
+		{"product_options object", func(r *pb.InsertOrderRequest) { r.Items[0].ProductOptions = `{"size":"L"}` }, nil},
+		{"bad product_options", func(r *pb.InsertOrderRequest) { r.Items[0].ProductOptions = `{"size":` }, []string{"items[0].product_options"}},
+		{"product_options array", func(r *pb.InsertOrderRequest) { r.Items[1].ProductOptions = `["L"]` }, []string{"items[1].product_options"}},
+		{"product_options null", func(r *pb.InsertOrderRequest) { r.Items[0].ProductOptions = "null" }, []string{"items[0].product_options"}},
+		{"empty items", func(r *pb.InsertOrderRequest) { r.Items, r.TotalPrice, r.Quantity = nil, 3000, 0 }, []string{"items"}},
+		{"negative price", func(r *pb.InsertOrderRequest) { r.Items[0].ProductPrice, r.TotalPrice = -1000, 3500 }, []string{"items[0].product_price"}},
+		{"empty product_name", func(r *pb.InsertOrderRequest) { r.Items[1].ProductName = "" }, []string{"items[1].product_name"}},
+		{"empty shipping_address", func(r *pb.InsertOrderRequest) { r.ShippingAddress = "" }, []string{"shipping_address"}},
+		// 길이는 바이트가 아니라 글자 수로 센다.
+		{"shipping_address at limit", func(r *pb.InsertOrderRequest) {
+			r.ShippingAddress = strings.Repeat("가", _maxShippingAddressLen)
+		}, nil},
+		{"shipping_address too long", func(r *pb.InsertOrderRequest) {
+			r.ShippingAddress = strings.Repeat("가", _maxShippingAddressLen+1)
+		}, []string{"shipping_address"}},
+		{"memo too long", func(r *pb.InsertOrderRequest) { r.Memo = strings.Repeat("a", _maxMemoLen+1) }, []string{"memo"}},
+		{"bad paid_at", func(r *pb.InsertOrderRequest) { r.PaidAt = "2025-01-02" }, []string{"paid_at"}},
+		// 여러 필드가 잘못되면 한 번에 모두 보고한다.
+		{"several at once", func(r *pb.InsertOrderRequest) {
+			r.UserId = ""
+			r.ShippingAddress = ""
+			r.Items[0].ProductId = "x"
+			r.Items[1].ProductOptions = "null"
+			r.Items[1].Quantity = 0
+			r.TotalPrice, r.Quantity = 5000, 2
+		}, []string{"user_id", "shipping_address", "items[0].product_id", "items[1].quantity", "items[1].product_options"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validInsertOrderRequest()
+			tt.modify(req)
+			_, err := parseInsertOrderRequest(req, nil)
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("parseInsertOrderRequest() error = %v", err)
+				}
+				return
+			}
+			if got := badRequestFields(t, err); !slices.Equal(got, tt.fields) {
+				t.Fatalf("violated fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestParseInsertOrderRequestPaidAt(t *testing.T) {
+	req := validInsertOrderRequest()
+	req.PaidAt = "2025-01-02T12:04:05+09:00"
+	in, err := parseInsertOrderRequest(req, nil)
+	if err != nil {
+		t.Fatalf("parseInsertOrderRequest() error = %v", err)
+	}
+	// paid_at 컬럼은 TIMESTAMP 라 UTC 로 맞춰 저장해야 한다.
+	if want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC); !in.PaidAt.Valid || in.PaidAt.Time != want {
+		t.Fatalf("PaidAt = %+v, want %v", in.PaidAt, want)
+	}
+}
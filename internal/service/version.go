@@ -0,0 +1,33 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/escape-ship/ordersrv/internal/infra/sqlc/postgresql"
+)
+
+// checkVersion 은 호출자가 기대한 버전과 현재 주문 버전이 다르면 ErrVersionConflict 를 반환한다.
+// expected 가 0 이면 확인하지 않는다.
+//
+// expected_version 은 주문을 읽고 나서 변경을 요청하는 gRPC 호출용이다. Kafka 결과 이벤트
+// (MarkOrderPaid, CompleteRefund)는 주문 버전을 싣고 오지 않고, 사용자가 본 상태가 아니라
+// 결제 서비스에서 이미 일어난 사실을 알리므로 버전과 상관없이 반영해야 한다. 대신 row lock 을 잡은 뒤
+// 상태 전이/금액을 다시 확인하고, 갱신은 versionedUpdate 로 읽은 버전에 대해서만 한다.
+func checkVersion(order postgresql.OrdersOrder, expected int32) error {
+	if expected != 0 && order.Version != expected {
+		return fmt.Errorf("%w: expected version %d, current %d", ErrVersionConflict, expected, order.Version)
+	}
+	return nil
+}
+
+// versionedUpdate 는 `WHERE id = $1 AND version = $n` 업데이트의 결과를 확인한다.
+// 갱신된 row 가 없으면 그 사이 다른 트랜잭션이 주문을 바꾼 것이다.
+func versionedUpdate(n int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package kafka
+
+import "errors"
+
+// PermanentError marks a handler error that will not succeed on retry.
+// The consumer forwards such messages to the dead-letter publisher right away.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err as a PermanentError. It returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err or any error it wraps is a PermanentError.
+func IsPermanent(err error) bool {
+	var perr *PermanentError
+	return errors.As(err, &perr)
+}
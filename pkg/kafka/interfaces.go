@@ -4,8 +4,23 @@ import (
 	"context"
 )
 
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Message is a single record for PublishBatch.
+type Message struct {
+	Key     []byte
+	Value   []byte
+	Headers []Header
+}
+
 type Publisher interface {
-	Publish(ctx context.Context, key, value []byte) error
+	Publish(ctx context.Context, key, value []byte, headers ...Header) error
+	// PublishBatch writes msgs in as few requests as possible and returns once
+	// all of them are acknowledged. On error some of msgs may have been written.
+	PublishBatch(ctx context.Context, msgs ...Message) error
 	Close() error
 }
 
@@ -13,5 +28,10 @@ type MessageHandler func(ctx context.Context, key, value []byte) error
 
 type Consumer interface {
 	Consume(ctx context.Context)
+	// Topic returns the topic the consumer reads from.
+	Topic() string
+	// Check dials the brokers and, if a max lag is set, compares the reader's
+	// lag against it. It returns nil if the consumer is healthy.
+	Check(ctx context.Context) error
 	Close() error
 }
@@ -2,27 +2,66 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 )
 
+// Dead-letter message headers
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+)
+
 // Publisher implementation
 
+// _publishBatchTimeout bounds how long a synchronous write waits for more
+// messages to fill a batch. kafka-go's default of one second would delay
+// every Publish call by a second.
+const _publishBatchTimeout = 10 * time.Millisecond
+
 type publisher struct {
 	writer *kafka.Writer
 }
 
-func NewPublisher(brokers []string, topic string) Publisher {
+func NewPublisher(brokers []string, topic string, opts ...PublisherOption) Publisher {
+	var cfg publisherConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	w := kafka.NewWriter(kafka.WriterConfig{
-		Brokers: brokers,
-		Topic:   topic,
+		Brokers:      brokers,
+		Topic:        topic,
+		Dialer:       cfg.security.dialer(),
+		BatchTimeout: _publishBatchTimeout,
 	})
 	return &publisher{writer: w}
 }
 
-func (p *publisher) Publish(ctx context.Context, key, value []byte) error {
-	msg := kafka.Message{Key: key, Value: value}
-	return p.writer.WriteMessages(ctx, msg)
+func (p *publisher) Publish(ctx context.Context, key, value []byte, headers ...Header) error {
+	return p.PublishBatch(ctx, Message{Key: key, Value: value, Headers: headers})
+}
+
+func (p *publisher) PublishBatch(ctx context.Context, msgs ...Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	kmsgs := make([]kafka.Message, len(msgs))
+	for i, m := range msgs {
+		kmsgs[i] = kafka.Message{Key: m.Key, Value: m.Value}
+		for _, h := range m.Headers {
+			kmsgs[i].Headers = append(kmsgs[i].Headers, kafka.Header{Key: h.Key, Value: h.Value})
+		}
+	}
+	return p.writer.WriteMessages(ctx, kmsgs...)
 }
 
 func (p *publisher) Close() error {
@@ -32,41 +71,176 @@ func (p *publisher) Close() error {
 // Consumer implementation
 
 type consumer struct {
-	reader  *kafka.Reader
-	handler MessageHandler
+	reader         *kafka.Reader
+	handler        MessageHandler
+	retry          RetryPolicy
+	deadLetter     Publisher
+	commitInterval time.Duration
+	security       Security
+	maxLag         int64
+	brokers        []string
 }
 
-func NewConsumer(brokers []string, topics map[string]MessageHandler, groupID string) []Consumer {
+func NewConsumer(brokers []string, topics map[string]MessageHandler, groupID string, opts ...ConsumerOption) []Consumer {
 	var res []Consumer
 	for topic, handler := range topics {
-		r := kafka.NewReader(kafka.ReaderConfig{
-			Brokers: brokers,
-			Topic:   topic,
-			GroupID: groupID,
+		c := &consumer{handler: handler, retry: DefaultRetryPolicy, brokers: brokers}
+		for _, opt := range opts {
+			opt(c)
+		}
+		c.reader = kafka.NewReader(kafka.ReaderConfig{
+			Brokers:        brokers,
+			Topic:          topic,
+			GroupID:        groupID,
+			CommitInterval: c.commitInterval,
+			Dialer:         c.security.dialer(),
 		})
-		res = append(res, &consumer{reader: r, handler: handler})
+		res = append(res, c)
 	}
 	return res
 }
 
+// Consume fetches messages without auto-commit and commits each offset only after the
+// message has been handled or forwarded to the dead-letter publisher, so a crash
+// mid-handler redelivers the message instead of losing it.
+//
+// Cancelling ctx stops fetching, but a handler call that is already running is
+// allowed to finish and its message is committed; Consume returns afterwards.
+// Call Close only after Consume has returned.
 func (c *consumer) Consume(ctx context.Context) {
+	readFailures := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			msg, err := c.reader.ReadMessage(ctx)
+			msg, err := c.reader.FetchMessage(ctx)
 			if err != nil {
+				// io.EOF: reader 가 닫혔다
+				if ctx.Err() != nil || errors.Is(err, io.EOF) {
+					return
+				}
+				readFailures++
+				log.Printf("kafka: read from %s failed (attempt %d): %v", c.reader.Config().Topic, readFailures, err)
+				if !sleep(ctx, c.retry.backoff(readFailures)) {
+					return
+				}
+				continue
+			}
+			readFailures = 0
+
+			if err := c.handle(ctx, msg); err != nil {
+				// 처리가 끝나지 않은 메시지는 커밋하지 않는다.
 				return
 			}
-			if err := c.handler(ctx, msg.Key, msg.Value); err != nil {
-				// Handle error from message handler
-				continue
+			// 종료 중이어도 처리가 끝난 메시지는 커밋한다.
+			if err := c.reader.CommitMessages(context.WithoutCancel(ctx), msg); err != nil {
+				log.Printf("kafka: commit %s/%d@%d failed: %v", msg.Topic, msg.Partition, msg.Offset, err)
 			}
 		}
 	}
 }
 
+func (c *consumer) Topic() string {
+	return c.reader.Config().Topic
+}
+
+// Check succeeds if any broker accepts a connection. The lag is the one the
+// reader saw on its last fetch, so a reader that has not fetched yet passes.
+// Stats resets the reader's counters; nothing else in this package reads them.
+func (c *consumer) Check(ctx context.Context) error {
+	if err := c.dialAny(ctx); err != nil {
+		return err
+	}
+	if c.maxLag > 0 {
+		if lag := c.reader.Stats().Lag; lag > c.maxLag {
+			return fmt.Errorf("kafka: %s lag %d exceeds %d", c.Topic(), lag, c.maxLag)
+		}
+	}
+	return nil
+}
+
+func (c *consumer) dialAny(ctx context.Context) error {
+	dialer := c.security.dialer()
+	var errs []error
+	for _, broker := range c.brokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn.Close()
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("kafka: no broker reachable: %w", errors.Join(errs...))
+}
+
+// handle runs the handler with retries and forwards the message to the dead-letter
+// publisher when the error is permanent or the retries are exhausted. It returns a
+// non-nil error only if ctx ended before the message was settled; the caller must
+// not commit the message in that case. The handler itself runs with a context
+// that is not cancelled with ctx, so a shutdown never aborts it halfway; ctx only
+// cuts the waits between attempts short.
+func (c *consumer) handle(ctx context.Context, msg kafka.Message) error {
+	info := MessageInfo{Topic: msg.Topic, Partition: msg.Partition, Offset: msg.Offset}
+	hctx := ContextWithMessageInfo(context.WithoutCancel(ctx), info)
+
+	var err error
+	attempt := 0
+	for {
+		attempt++
+		if err = c.handler(hctx, msg.Key, msg.Value); err == nil {
+			return nil
+		}
+		if IsPermanent(err) || attempt >= c.retry.MaxAttempts {
+			break
+		}
+		log.Printf("kafka: handler for %s/%d@%d failed (attempt %d), retrying: %v", msg.Topic, msg.Partition, msg.Offset, attempt, err)
+		if !sleep(ctx, c.retry.backoff(attempt)) {
+			return ctx.Err()
+		}
+	}
+
+	log.Printf("kafka: giving up on %s/%d@%d after %d attempt(s): %v", msg.Topic, msg.Partition, msg.Offset, attempt, err)
+	return c.forwardToDeadLetter(ctx, msg, err, attempt)
+}
+
+// forwardToDeadLetter keeps retrying the dead-letter publish until it succeeds or ctx ends,
+// since committing past a message that never reached the dead-letter topic would lose it.
+func (c *consumer) forwardToDeadLetter(ctx context.Context, msg kafka.Message, cause error, attempts int) error {
+	if c.deadLetter == nil {
+		return nil
+	}
+	headers := []Header{
+		{Key: HeaderOriginalTopic, Value: []byte(msg.Topic)},
+		{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		{Key: HeaderError, Value: []byte(cause.Error())},
+		{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+	}
+	for retry := 1; ; retry++ {
+		err := c.deadLetter.Publish(ctx, msg.Key, msg.Value, headers...)
+		if err == nil {
+			return nil
+		}
+		log.Printf("kafka: dead-letter publish for %s/%d@%d failed: %v", msg.Topic, msg.Partition, msg.Offset, err)
+		if !sleep(ctx, c.retry.backoff(retry)) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Close closes the reader, flushing pending offset commits.
 func (c *consumer) Close() error {
 	return c.reader.Close()
 }
+
+// sleep waits for d or until ctx is done. It reports whether the full duration elapsed.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,202 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		retry int
+		want  time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second}, // 1.6s 는 MaxBackoff 로 잘린다
+		{50, time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.backoff(tt.retry); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.retry, got, tt.want)
+		}
+	}
+}
+
+func TestIsPermanent(t *testing.T) {
+	base := errors.New("boom")
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain", base, false},
+		{"permanent", Permanent(base), true},
+		{"wrapped permanent", fmt.Errorf("handler: %w", Permanent(base)), true},
+		{"joined", errors.Join(base, Permanent(base)), true},
+	}
+	for _, tt := range tests {
+		if got := IsPermanent(tt.err); got != tt.want {
+			t.Errorf("%s: IsPermanent() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+	if !errors.Is(Permanent(base), base) {
+		t.Error("Permanent(err) does not unwrap to err")
+	}
+}
+
+type recordingPublisher struct {
+	fails    int // 처음 fails 번은 실패한다
+	calls    int
+	messages [][]Header
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, _, _ []byte, headers ...Header) error {
+	p.calls++
+	if p.calls <= p.fails {
+		return errors.New("broker unavailable")
+	}
+	p.messages = append(p.messages, headers)
+	return nil
+}
+
+func (p *recordingPublisher) PublishBatch(ctx context.Context, msgs ...Message) error {
+	for _, m := range msgs {
+		if err := p.Publish(ctx, m.Key, m.Value, m.Headers...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func TestConsumerHandle(t *testing.T) {
+	errTransient := errors.New("db down")
+	errBad := Permanent(errors.New("bad payload"))
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	tests := []struct {
+		name         string
+		results      []error // handler 가 호출마다 반환할 에러
+		wantCalls    int
+		wantDLQ      bool
+		wantAttempts int
+	}{
+		{"success", []error{nil}, 1, false, 0},
+		{"transient then success", []error{errTransient, errTransient, nil}, 3, false, 0},
+		{"retries exhausted", []error{errTransient, errTransient, errTransient}, 3, true, 3},
+		{"permanent is not retried", []error{errBad}, 1, true, 1},
+		{"permanent after transient", []error{errTransient, errBad}, 2, true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			dlq := &recordingPublisher{}
+			c := &consumer{
+				retry:      policy,
+				deadLetter: dlq,
+				handler: func(ctx context.Context, _, _ []byte) error {
+					if _, ok := MessageInfoFromContext(ctx); !ok {
+						t.Error("handler ctx has no message info")
+					}
+					err := tt.results[calls]
+					calls++
+					return err
+				},
+			}
+			msg := kafka.Message{Topic: "payment-succeeded", Partition: 2, Offset: 42}
+			if err := c.handle(context.Background(), msg); err != nil {
+				t.Fatalf("handle() = %v, want nil", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if got := len(dlq.messages) == 1; got != tt.wantDLQ {
+				t.Fatalf("dead-lettered = %v, want %v", got, tt.wantDLQ)
+			}
+			if !tt.wantDLQ {
+				return
+			}
+			headers := map[string]string{}
+			for _, h := range dlq.messages[0] {
+				headers[h.Key] = string(h.Value)
+			}
+			want := map[string]string{
+				HeaderOriginalTopic:     "payment-succeeded",
+				HeaderOriginalPartition: "2",
+				HeaderOriginalOffset:    "42",
+				HeaderAttempts:          strconv.Itoa(tt.wantAttempts),
+			}
+			for k, v := range want {
+				if headers[k] != v {
+					t.Errorf("header %s = %q, want %q", k, headers[k], v)
+				}
+			}
+			if headers[HeaderError] == "" {
+				t.Errorf("header %s is empty", HeaderError)
+			}
+		})
+	}
+}
+
+func TestConsumerHandleRetriesDeadLetterPublish(t *testing.T) {
+	dlq := &recordingPublisher{fails: 2}
+	c := &consumer{
+		retry:      RetryPolicy{MaxAttempts: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+		deadLetter: dlq,
+		handler:    func(context.Context, []byte, []byte) error { return errors.New("boom") },
+	}
+	if err := c.handle(context.Background(), kafka.Message{}); err != nil {
+		t.Fatalf("handle() = %v, want nil", err)
+	}
+	if dlq.calls != 3 || len(dlq.messages) != 1 {
+		t.Fatalf("dead-letter calls = %d, published = %d; want 3, 1", dlq.calls, len(dlq.messages))
+	}
+}
+
+func TestConsumerHandleStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	dlq := &recordingPublisher{}
+	c := &consumer{
+		retry:      RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour},
+		deadLetter: dlq,
+		handler: func(hctx context.Context, _, _ []byte) error {
+			cancel()
+			// 실행 중인 handler 는 종료로 중단되지 않는다.
+			if hctx.Err() != nil {
+				t.Errorf("handler ctx cancelled: %v", hctx.Err())
+			}
+			return errors.New("boom")
+		},
+	}
+	// 처리되지 않은 메시지는 커밋되면 안 되므로 에러를 돌려줘야 한다.
+	if err := c.handle(ctx, kafka.Message{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("handle() = %v, want context.Canceled", err)
+	}
+	if len(dlq.messages) != 0 {
+		t.Fatal("message was dead-lettered after cancel")
+	}
+}
+
+func TestNewPublisherBatchTimeout(t *testing.T) {
+	p := NewPublisher([]string{"127.0.0.1:1"}, "orders").(*publisher)
+	t.Cleanup(func() { p.Close() })
+	// 동기 쓰기가 배치를 채우려고 기본값 1초씩 기다리면 안 된다.
+	if got := p.writer.BatchTimeout; got != _publishBatchTimeout {
+		t.Errorf("writer.BatchTimeout = %v, want %v", got, _publishBatchTimeout)
+	}
+}
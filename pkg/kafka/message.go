@@ -0,0 +1,23 @@
+package kafka
+
+import "context"
+
+// MessageInfo identifies the Kafka message a MessageHandler is currently processing.
+type MessageInfo struct {
+	Topic     string
+	Partition int
+	Offset    int64
+}
+
+type messageInfoKey struct{}
+
+// ContextWithMessageInfo returns a copy of ctx carrying info.
+func ContextWithMessageInfo(ctx context.Context, info MessageInfo) context.Context {
+	return context.WithValue(ctx, messageInfoKey{}, info)
+}
+
+// MessageInfoFromContext returns the MessageInfo the consumer attached to ctx, if any.
+func MessageInfoFromContext(ctx context.Context) (MessageInfo, bool) {
+	info, ok := ctx.Value(messageInfoKey{}).(MessageInfo)
+	return info, ok
+}
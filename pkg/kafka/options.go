@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+)
+
+type ConsumerOption func(*consumer)
+
+type PublisherOption func(*publisherConfig)
+
+type publisherConfig struct {
+	security Security
+}
+
+// Security holds the TLS and SASL settings used to connect to the brokers.
+// The zero value connects in plaintext without authentication.
+type Security struct {
+	TLS  *tls.Config
+	SASL sasl.Mechanism
+}
+
+func (s Security) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		TLS:           s.TLS,
+		SASLMechanism: s.SASL,
+	}
+}
+
+// WithSecurity sets the TLS and SASL settings of the consumer's reader.
+func WithSecurity(s Security) ConsumerOption {
+	return func(c *consumer) {
+		c.security = s
+	}
+}
+
+// WithPublisherSecurity sets the TLS and SASL settings of the publisher's writer.
+func WithPublisherSecurity(s Security) PublisherOption {
+	return func(p *publisherConfig) {
+		p.security = s
+	}
+}
+
+// WithRetryPolicy sets how many times and how often a failing message is retried.
+func WithRetryPolicy(policy RetryPolicy) ConsumerOption {
+	return func(c *consumer) {
+		c.retry = policy
+	}
+}
+
+// WithDeadLetter forwards messages that failed permanently or ran out of retries to p.
+// Without it such messages are logged and dropped.
+func WithDeadLetter(p Publisher) ConsumerOption {
+	return func(c *consumer) {
+		c.deadLetter = p
+	}
+}
+
+// WithCommitInterval batches offset commits and flushes them every d.
+// With the default of 0 each message is committed synchronously after it is handled.
+func WithCommitInterval(d time.Duration) ConsumerOption {
+	return func(c *consumer) {
+		c.commitInterval = d
+	}
+}
+
+// RetryPolicy is an exponential backoff policy for handler and read errors.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+}
+
+// backoff returns the delay before the given retry (1-based).
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// WithMaxLag makes Check fail once the reader is more than n messages behind.
+// 0 disables the lag check.
+func WithMaxLag(n int64) ConsumerOption {
+	return func(c *consumer) {
+		c.maxLag = n
+	}
+}
@@ -1,9 +1,36 @@
 package postgres
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
 
 type DBEngine interface {
 	Configure(opts ...Option) DBEngine
+	// GetDB returns the primary. Use it for writes and for reads that must
+	// see the caller's own writes.
 	GetDB() *sql.DB
+	// GetReadDB returns a healthy replica, or the primary if there is none.
+	// Replicas may lag behind the primary.
+	GetReadDB() *sql.DB
+	// Ping checks that the primary is reachable.
+	Ping(ctx context.Context) error
+	WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*sql.Tx) error) error
 	Close()
 }
+
+// PoolEngine is a DBEngine backed by pgxpool, created with NewPool. GetDB and
+// GetReadDB wrap the same pools, so database/sql and native pgx callers share
+// connections; the pools give access to COPY, batches and notifications.
+type PoolEngine interface {
+	DBEngine
+	// Pool returns the primary pool.
+	Pool() *pgxpool.Pool
+	// ReadPool returns a healthy replica pool, or the primary if there is none.
+	ReadPool() *pgxpool.Pool
+	// Listen calls fn for every notification on channel until ctx is done.
+	Listen(ctx context.Context, channel string, fn func(*pgconn.Notification)) error
+}
@@ -4,14 +4,61 @@ import "time"
 
 type Option func(*postgres)
 
+// WithConnAttempts sets how many times New pings the database before giving up.
 func WithConnAttempts(attempts int) Option {
 	return func(m *postgres) {
 		m.connAttempts = attempts
 	}
 }
 
+// WithConnTimeout sets the timeout of each ping attempt in New.
 func WithConnTimeout(timeout time.Duration) Option {
 	return func(m *postgres) {
 		m.connTimeout = timeout
 	}
 }
+
+// WithMaxOpenConns limits the number of open connections. 0 means unlimited.
+func WithMaxOpenConns(n int) Option {
+	return func(m *postgres) {
+		m.maxOpenConns = n
+	}
+}
+
+// WithMaxIdleConns limits the number of idle connections kept in the pool.
+func WithMaxIdleConns(n int) Option {
+	return func(m *postgres) {
+		m.maxIdleConns = n
+	}
+}
+
+// WithConnMaxLifetime closes connections after they have been open for d. 0 means forever.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(m *postgres) {
+		m.connMaxLifetime = d
+	}
+}
+
+// WithTxRetries sets how many times WithTx re-runs a transaction that failed
+// with a serialization failure or deadlock. 0 disables retries.
+func WithTxRetries(n int) Option {
+	return func(m *postgres) {
+		m.txRetries = n
+	}
+}
+
+// WithReplicas adds read replicas served by GetReadDB. They share the pool
+// options of the primary.
+func WithReplicas(urls ...DBConnString) Option {
+	return func(m *postgres) {
+		m.replicaURLs = append(m.replicaURLs, urls...)
+	}
+}
+
+// WithReplicaCheckInterval sets how often replicas are pinged to decide
+// whether GetReadDB may return them.
+func WithReplicaCheckInterval(d time.Duration) Option {
+	return func(m *postgres) {
+		m.replicaCheckInterval = d
+	}
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var _ PoolEngine = (*postgres)(nil)
+
+// NewPool is like New but opens pgxpool pools instead of database/sql ones.
+// WithMaxOpenConns and WithConnMaxLifetime size the pools; WithMaxIdleConns
+// only applies to the *sql.DB wrappers.
+func NewPool(url DBConnString, opts ...Option) (PoolEngine, error) {
+	pg, err := connect(url, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pg, nil
+}
+
+func (m *postgres) Pool() *pgxpool.Pool {
+	return m.pool
+}
+
+func (m *postgres) ReadPool() *pgxpool.Pool {
+	if r := m.nextReplica(); r != nil {
+		return r.pool
+	}
+	return m.pool
+}
+
+// Listen holds a dedicated connection for the lifetime of ctx. The connection
+// is taken out of the pool and closed afterwards rather than returned with
+// LISTEN still active. It returns ctx.Err() once ctx is done.
+func (m *postgres) Listen(ctx context.Context, channel string, fn func(*pgconn.Notification)) error {
+	if m.pool == nil {
+		return fmt.Errorf("postgres: Listen requires an engine created with NewPool")
+	}
+	pc, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres: acquire: %w", err)
+	}
+	conn := pc.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("postgres: listen %q: %w", channel, err)
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("postgres: wait for notification: %w", err)
+		}
+		fn(n)
+	}
+}
@@ -1,58 +1,189 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"sync/atomic"
 	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 )
 
 const (
+	_driverName          = "pgx"
 	_defaultConnAttempts = 3
 	_defaultConnTimeout  = time.Second
+	_defaultMaxIdleConns = 2
+	_connBackoff         = 500 * time.Millisecond
+	_maxConnBackoff      = 10 * time.Second
 )
 
 type DBConnString string
 
 type postgres struct {
-	connAttempts int
-	connTimeout  time.Duration
+	connAttempts    int
+	connTimeout     time.Duration
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+	txRetries       int
+
+	replicaURLs          []DBConnString
+	replicaCheckInterval time.Duration
+
+	// native is set by NewPool: connections come from pgxpool and the
+	// *sql.DB handles are thin wrappers around the pools.
+	native bool
 
-	db *sql.DB
+	db            *sql.DB
+	pool          *pgxpool.Pool
+	replicas      []*replica
+	replicaCursor atomic.Uint64
+	stopWatch     chan struct{}
+	watchDone     chan struct{}
 }
 
 var _ DBEngine = (*postgres)(nil)
 
-func New(url DBConnString) (DBEngine, error) {
+// New opens a connection pool and pings the database until it answers or the
+// configured attempts run out, backing off exponentially between attempts.
+// It returns an error if the database is unreachable. Replicas passed with
+// WithReplicas are opened as well and serve GetReadDB while they are healthy.
+func New(url DBConnString, opts ...Option) (DBEngine, error) {
+	pg, err := connect(url, false, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pg, nil
+}
+
+func connect(url DBConnString, native bool, opts []Option) (*postgres, error) {
 	pg := &postgres{
+		native:       native,
 		connAttempts: _defaultConnAttempts,
 		connTimeout:  _defaultConnTimeout,
+		maxIdleConns: _defaultMaxIdleConns,
+		txRetries:    _defaultTxRetries,
+
+		replicaCheckInterval: _defaultReplicaCheckInterval,
+	}
+	for _, opt := range opts {
+		opt(pg)
+	}
+
+	db, pool, err := pg.open(url)
+	if err != nil {
+		return nil, err
 	}
+	pg.db, pg.pool = db, pool
 
-	var err error
-	for pg.connAttempts > 0 {
-		pg.db, err = sql.Open("postgres", string(url))
+	backoff := _connBackoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), pg.connTimeout)
+		err = db.PingContext(ctx)
+		cancel()
 		if err == nil {
 			break
 		}
-		time.Sleep(pg.connTimeout)
-		pg.connAttempts--
+		if attempt >= pg.connAttempts {
+			pg.closePrimary()
+			return nil, fmt.Errorf("postgres: ping failed after %d attempt(s): %w", pg.connAttempts, err)
+		}
+		time.Sleep(backoff)
+		backoff = min(backoff*2, _maxConnBackoff)
 	}
 
+	if err := pg.openReplicas(); err != nil {
+		pg.closePrimary()
+		return nil, err
+	}
+	pg.applyPoolSettings()
+	if len(pg.replicas) > 0 {
+		if pg.replicaCheckInterval <= 0 {
+			pg.replicaCheckInterval = _defaultReplicaCheckInterval
+		}
+		pg.stopWatch = make(chan struct{})
+		pg.watchDone = make(chan struct{})
+		go pg.watchReplicas()
+	}
 	return pg, nil
 }
 
+// Configure applies opts to an already connected engine. Only the pool options
+// take effect here; connection attempt and replica options must be passed to New.
 func (m *postgres) Configure(opts ...Option) DBEngine {
 	for _, opt := range opts {
 		opt(m)
 	}
+	m.applyPoolSettings()
 	return m
 }
 
+func (m *postgres) applyPoolSettings() {
+	dbs := []*sql.DB{m.db}
+	for _, r := range m.replicas {
+		dbs = append(dbs, r.db)
+	}
+	for _, db := range dbs {
+		db.SetMaxOpenConns(m.maxOpenConns)
+		db.SetMaxIdleConns(m.maxIdleConns)
+		db.SetConnMaxLifetime(m.connMaxLifetime)
+	}
+}
+
 func (m *postgres) GetDB() *sql.DB {
 	return m.db
 }
 
+func (m *postgres) Ping(ctx context.Context) error {
+	return m.db.PingContext(ctx)
+}
+
 func (m *postgres) Close() {
+	if m.stopWatch != nil {
+		close(m.stopWatch)
+		<-m.watchDone
+		m.stopWatch = nil
+	}
+	m.closeReplicas()
+	m.closePrimary()
+}
+
+// open opens a *sql.DB for url, backed by a pgxpool.Pool in native mode.
+func (m *postgres) open(url DBConnString) (*sql.DB, *pgxpool.Pool, error) {
+	if !m.native {
+		db, err := sql.Open(_driverName, string(url))
+		if err != nil {
+			return nil, nil, fmt.Errorf("postgres: open: %w", err)
+		}
+		return db, nil, nil
+	}
+
+	cfg, err := pgxpool.ParseConfig(string(url))
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres: parse config: %w", err)
+	}
+	// pgxpool sizes are fixed at creation, so Configure cannot change them later.
+	if m.maxOpenConns > 0 {
+		cfg.MaxConns = int32(m.maxOpenConns)
+	}
+	if m.connMaxLifetime > 0 {
+		cfg.MaxConnLifetime = m.connMaxLifetime
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("postgres: open pool: %w", err)
+	}
+	return stdlib.OpenDBFromPool(pool), pool, nil
+}
+
+func (m *postgres) closePrimary() {
 	if m.db != nil {
 		m.db.Close()
 	}
+	if m.pool != nil {
+		m.pool.Close()
+	}
 }
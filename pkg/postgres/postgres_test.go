@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewUnreachable(t *testing.T) {
+	opts := []Option{WithConnAttempts(2), WithConnTimeout(time.Second)}
+	tests := []struct {
+		name    string
+		connect func() (DBEngine, error)
+	}{
+		{"New", func() (DBEngine, error) { return New(_unreachableDSN, opts...) }},
+		{"NewPool", func() (DBEngine, error) { return NewPool(_unreachableDSN, opts...) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, err := tt.connect()
+			if err == nil {
+				pg.Close()
+				t.Fatal("connected to an unreachable server")
+			}
+			if pg != nil {
+				t.Errorf("engine = %v, want nil on error", pg)
+			}
+			if !strings.Contains(err.Error(), "after 2 attempt(s)") {
+				t.Errorf("error = %v, want it to report 2 attempts", err)
+			}
+		})
+	}
+}
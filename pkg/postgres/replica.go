@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const _defaultReplicaCheckInterval = 5 * time.Second
+
+type replica struct {
+	db      *sql.DB
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// openReplicas opens a pool for every replica URL and pings each once. A
+// replica that does not answer is not an error; it starts out unhealthy and
+// is picked up by the health check once it comes back.
+func (m *postgres) openReplicas() error {
+	for _, url := range m.replicaURLs {
+		db, pool, err := m.open(url)
+		if err != nil {
+			m.closeReplicas()
+			return fmt.Errorf("replica: %w", err)
+		}
+		r := &replica{db: db, pool: pool}
+		m.replicas = append(m.replicas, r)
+		m.checkReplica(r)
+	}
+	return nil
+}
+
+// GetReadDB returns the next healthy replica in round-robin order. It falls
+// back to the primary when no replicas are configured or none is healthy.
+func (m *postgres) GetReadDB() *sql.DB {
+	if r := m.nextReplica(); r != nil {
+		return r.db
+	}
+	return m.db
+}
+
+func (m *postgres) nextReplica() *replica {
+	n := uint64(len(m.replicas))
+	start := m.replicaCursor.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := m.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+func (m *postgres) checkReplica(r *replica) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.connTimeout)
+	defer cancel()
+	r.healthy.Store(r.db.PingContext(ctx) == nil)
+}
+
+// watchReplicas pings every replica each replicaCheckInterval until Close.
+// Close waits for it to return before closing the replicas, so a check never
+// marks a closed replica healthy again.
+func (m *postgres) watchReplicas() {
+	defer close(m.watchDone)
+
+	ticker := time.NewTicker(m.replicaCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stopWatch:
+			return
+		case <-ticker.C:
+			for _, r := range m.replicas {
+				m.checkReplica(r)
+			}
+		}
+	}
+}
+
+// closeReplicas closes every replica pool. m.replicas itself is left in place
+// because GetReadDB reads it without a lock; it is only written while the
+// engine is being opened. Replicas are marked unhealthy first so that
+// GetReadDB falls back to the primary from then on.
+func (m *postgres) closeReplicas() {
+	for _, r := range m.replicas {
+		r.healthy.Store(false)
+		r.db.Close()
+		if r.pool != nil {
+			r.pool.Close()
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	_defaultTxRetries = 3
+	_txBackoff        = 20 * time.Millisecond
+	_maxTxBackoff     = time.Second
+)
+
+// SQLSTATE codes after which the whole transaction can safely be re-run.
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// WithTx runs fn inside a transaction started with ctx and opts (nil means the
+// driver defaults, i.e. READ COMMITTED). The transaction is committed when fn
+// returns nil and rolled back otherwise; a failed Commit is returned to the
+// caller. If the transaction fails with a serialization failure or a deadlock,
+// fn is run again in a fresh transaction up to the configured number of
+// retries, so fn must not have side effects outside tx.
+func (m *postgres) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*sql.Tx) error) error {
+	backoff := _txBackoff
+	for attempt := 0; ; attempt++ {
+		err := m.runTx(ctx, opts, fn)
+		if err == nil || !IsRetryable(err) || attempt >= m.txRetries {
+			return err
+		}
+
+		// jitter so that transactions that collided don't collide again
+		wait := backoff/2 + rand.N(backoff/2+1)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, _maxTxBackoff)
+	}
+}
+
+func (m *postgres) runTx(ctx context.Context, opts *sql.TxOptions, fn func(*sql.Tx) error) (err error) {
+	tx, err := m.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("postgres: begin: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: commit: %w", err)
+	}
+	return nil
+}
+
+// IsRetryable reports whether err is a Postgres serialization failure (40001)
+// or deadlock (40P01), after which the transaction can be retried as a whole.
+func IsRetryable(err error) bool {
+	// both pgconn.PgError and pq.Error expose the SQLSTATE this way
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.SQLState() {
+	case sqlStateSerializationFailure, sqlStateDeadlockDetected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, true},
+		{"deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"wrapped", fmt.Errorf("update order: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"joined", errors.Join(errors.New("rollback failed"), &pgconn.PgError{Code: "40P01"}), true},
+		{"lib/pq", &pq.Error{Code: "40001"}, true},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, false},
+		{"not a postgres error", sql.ErrNoRows, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable(%v) = %v, want %v", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+// txConnector opens connections whose transactions always begin and commit.
+type txConnector struct{}
+
+func (txConnector) Connect(context.Context) (driver.Conn, error) { return txConn{}, nil }
+func (txConnector) Driver() driver.Driver                        { return nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("txConn: prepare not supported")
+}
+func (txConn) Close() error              { return nil }
+func (txConn) Begin() (driver.Tx, error) { return txConn{}, nil }
+func (txConn) Commit() error             { return nil }
+func (txConn) Rollback() error           { return nil }
+
+func TestWithTxRetries(t *testing.T) {
+	errSerialization := &pgconn.PgError{Code: "40001"}
+	errUnique := &pgconn.PgError{Code: "23505"}
+	tests := []struct {
+		name    string
+		errs    []error // fn returns these in order, then nil
+		calls   int
+		wantErr error
+	}{
+		{"success", nil, 1, nil},
+		{"retried until success", []error{errSerialization, errSerialization}, 3, nil},
+		// _defaultTxRetries retries after the first attempt, then the error is returned
+		{"retries exhausted", []error{errSerialization, errSerialization, errSerialization, errSerialization, errSerialization}, 4, errSerialization},
+		{"not retryable", []error{errUnique}, 1, errUnique},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sql.OpenDB(txConnector{})
+			t.Cleanup(func() { db.Close() })
+			m := &postgres{db: db, txRetries: _defaultTxRetries}
+
+			calls := 0
+			err := m.WithTx(context.Background(), nil, func(*sql.Tx) error {
+				calls++
+				if calls <= len(tt.errs) {
+					return tt.errs[calls-1]
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WithTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.calls {
+				t.Errorf("fn called %d time(s), want %d", calls, tt.calls)
+			}
+		})
+	}
+}